@@ -0,0 +1,90 @@
+// Command timebookfmt rewrites timebook files into their canonical form.
+//
+// Usage:
+//
+//	timebookfmt [-l] [-w] [file ...]
+//
+// Without files, the content is read from stdin and written to stdout.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"timebook/utils"
+)
+
+var (
+	listFiles  = flag.Bool("l", false, "list files whose formatting differs from the canonical form")
+	writeFiles = flag.Bool("w", false, "write the result to the file instead of stdout")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: timebookfmt [-l] [-w] [file ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		if *writeFiles {
+			fmt.Fprintln(os.Stderr, "timebookfmt: cannot use -w with stdin")
+			os.Exit(2)
+		}
+
+		if err := formatStream(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "timebookfmt: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	exitCode := 0
+	for _, filePath := range flag.Args() {
+		if err := formatPath(filePath); err != nil {
+			fmt.Fprintf(os.Stderr, "timebookfmt: %s: %v\n", filePath, err)
+			exitCode = 1
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// Format the content of the reader and write it to the writer
+func formatStream(reader io.Reader, writer io.Writer) error {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(utils.FormatContent(content))
+	return err
+}
+
+// Format a single file as requested by the flags
+func formatPath(filePath string) error {
+	if *writeFiles {
+		changed, err := utils.FormatFile(filePath)
+		if err == nil && changed && *listFiles {
+			fmt.Println(filePath)
+		}
+		return err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	formattedContent := utils.FormatContent(content)
+
+	if *listFiles {
+		if !bytes.Equal(content, formattedContent) {
+			fmt.Println(filePath)
+		}
+		return nil
+	}
+
+	_, err = os.Stdout.Write(formattedContent)
+	return err
+}
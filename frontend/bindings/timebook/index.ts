@@ -7,8 +7,29 @@ export {
 };
 
 export {
+    CategoryComparisonEntry,
+    CategoryDefinition,
     CategoryShort,
+    CategorySummaryEntry,
+    Comparison,
+    ComparisonEntry,
+    Diagnostic,
+    FileSummary,
+    Gap,
+    LabelSummaryEntry,
+    Language,
+    Metadata,
+    Overlap,
+    Period,
+    PeriodSummary,
+    ScopeSummary,
+    Settings,
+    Severity,
+    SortKey,
     SummaryEntry,
+    TaskDefinition,
+    TaskEntry,
     TaskShort,
+    Taxonomy,
     TimebookSummary
 } from "./models.js";
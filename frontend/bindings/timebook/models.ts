@@ -5,18 +5,1106 @@
 // @ts-ignore: Unused imports
 import { Create as $Create } from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../time/models.js";
+
+/**
+ * Change of a whole category between two timebooks
+ */
+export class CategoryComparisonEntry {
+    /**
+     * The category short code (e.g. "M" for meetings)
+     */
+    "CategoryShort": CategoryShort;
+
+    /**
+     * The full name of the category (e.g. "Meetings")
+     */
+    "CategoryName": string;
+
+    /**
+     * Whether the category holds breaks or other non-working time
+     * If set, all factors are zero.
+     */
+    "NonWorking": boolean;
+
+    /**
+     * Minutes received in the previous and the current timebook
+     */
+    "PreviousMinutes": number;
+    "CurrentMinutes": number;
+    "DeltaMinutes": number;
+
+    /**
+     * Factors of received minutes to total minutes and their difference
+     * NOTE: These are factors between 0 and 1, not percentages.
+     */
+    "PreviousFactorOfTotal": number;
+    "CurrentFactorOfTotal": number;
+    "DeltaFactorOfTotal": number;
+
+    /**
+     * Whether the category was logged in the current or the previous timebook only
+     */
+    "New": boolean;
+    "Disappeared": boolean;
+
+    /** Creates a new CategoryComparisonEntry instance. */
+    constructor($$source: Partial<CategoryComparisonEntry> = {}) {
+        if (!("CategoryShort" in $$source)) {
+            this["CategoryShort"] = CategoryShort.$zero;
+        }
+        if (!("CategoryName" in $$source)) {
+            this["CategoryName"] = "";
+        }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
+        if (!("PreviousMinutes" in $$source)) {
+            this["PreviousMinutes"] = 0;
+        }
+        if (!("CurrentMinutes" in $$source)) {
+            this["CurrentMinutes"] = 0;
+        }
+        if (!("DeltaMinutes" in $$source)) {
+            this["DeltaMinutes"] = 0;
+        }
+        if (!("PreviousFactorOfTotal" in $$source)) {
+            this["PreviousFactorOfTotal"] = 0;
+        }
+        if (!("CurrentFactorOfTotal" in $$source)) {
+            this["CurrentFactorOfTotal"] = 0;
+        }
+        if (!("DeltaFactorOfTotal" in $$source)) {
+            this["DeltaFactorOfTotal"] = 0;
+        }
+        if (!("New" in $$source)) {
+            this["New"] = false;
+        }
+        if (!("Disappeared" in $$source)) {
+            this["Disappeared"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CategoryComparisonEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CategoryComparisonEntry {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CategoryComparisonEntry($$parsedSource as Partial<CategoryComparisonEntry>);
+    }
+}
+
+/**
+ * Definition of a single category
+ */
+export class CategoryDefinition {
+    /**
+     * Single letter category short (e.g. "M" for meetings)
+     */
+    "Short": CategoryShort;
+
+    /**
+     * The full name of the category (e.g. "Meetings")
+     */
+    "Name": string;
+
+    /**
+     * The full names of the category per language, preferred over Name
+     */
+    "Names": { [_: Language]: string };
+
+    /**
+     * Whether tasks of the category are breaks or other non-working time
+     */
+    "NonWorking": boolean;
+
+    /** Creates a new CategoryDefinition instance. */
+    constructor($$source: Partial<CategoryDefinition> = {}) {
+        if (!("Short" in $$source)) {
+            this["Short"] = CategoryShort.$zero;
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("Names" in $$source)) {
+            this["Names"] = {};
+        }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CategoryDefinition instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CategoryDefinition {
+        const $$createField2_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Names" in $$parsedSource) {
+            $$parsedSource["Names"] = $$createField2_0($$parsedSource["Names"]);
+        }
+        return new CategoryDefinition($$parsedSource as Partial<CategoryDefinition>);
+    }
+}
+
+/**
+ * A category short code (e.g. "M" for meetings)
+ */
 export enum CategoryShort {
     /**
      * The Go zero value for the underlying type of the enum.
      */
     $zero = "",
 
-    PlannedWorkCategory = "A",
-    UnplannedWorkCategory = "O",
-    MeetingsCategory = "M",
-    MaintenanceCategory = "W",
-    SupportCategory = "S",
-    MiscellaneousCategory = "V",
+    PlannedWorkCategory = "A",
+    UnplannedWorkCategory = "O",
+    MeetingsCategory = "M",
+    MaintenanceCategory = "W",
+    SupportCategory = "S",
+    MiscellaneousCategory = "V",
+    BreakCategory = "P",
+};
+
+/**
+ * A summary entry for a whole category, rolled up from its tasks
+ */
+export class CategorySummaryEntry {
+    /**
+     * The category short code (e.g. "M" for meetings)
+     */
+    "CategoryShort": CategoryShort;
+
+    /**
+     * The full name of the category (e.g. "Meetings")
+     */
+    "CategoryName": string;
+
+    /**
+     * Whether the category holds breaks or other non-working time
+     * If set, FactorOfTotal is always zero.
+     */
+    "NonWorking": boolean;
+
+    /**
+     * The task short codes rolled up into this category
+     */
+    "TaskShorts": TaskShort[];
+
+    /**
+     * Number of tasks for this category
+     */
+    "CountTasks": number;
+
+    /**
+     * Minutes expected for all tasks of this category
+     * If zero, no expectation is set
+     */
+    "ExpectedMinutes": number;
+
+    /**
+     * Minutes actually received for all tasks of this category
+     */
+    "ReceivedMinutes": number;
+
+    /**
+     * Factor of received minutes to expected minutes
+     * If ExpectedMinutes is zero, this will also be zero.
+     * NOTE: This is a factor between 0 and 1, not a percentage.
+     */
+    "FactorOfExpected": number;
+
+    /**
+     * Factor of received minutes to total minutes
+     * NOTE: This is a factor calculated over all tasks in the timebook.
+     * NOTE: This is a factor between 0 and 1, not a percentage.
+     */
+    "FactorOfTotal": number;
+
+    /** Creates a new CategorySummaryEntry instance. */
+    constructor($$source: Partial<CategorySummaryEntry> = {}) {
+        if (!("CategoryShort" in $$source)) {
+            this["CategoryShort"] = CategoryShort.$zero;
+        }
+        if (!("CategoryName" in $$source)) {
+            this["CategoryName"] = "";
+        }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
+        if (!("TaskShorts" in $$source)) {
+            this["TaskShorts"] = [];
+        }
+        if (!("CountTasks" in $$source)) {
+            this["CountTasks"] = 0;
+        }
+        if (!("ExpectedMinutes" in $$source)) {
+            this["ExpectedMinutes"] = 0;
+        }
+        if (!("ReceivedMinutes" in $$source)) {
+            this["ReceivedMinutes"] = 0;
+        }
+        if (!("FactorOfExpected" in $$source)) {
+            this["FactorOfExpected"] = 0;
+        }
+        if (!("FactorOfTotal" in $$source)) {
+            this["FactorOfTotal"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CategorySummaryEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CategorySummaryEntry {
+        const $$createField3_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("TaskShorts" in $$parsedSource) {
+            $$parsedSource["TaskShorts"] = $$createField3_0($$parsedSource["TaskShorts"]);
+        }
+        return new CategorySummaryEntry($$parsedSource as Partial<CategorySummaryEntry>);
+    }
+}
+
+/**
+ * Changes between two timebooks, e.g. of two consecutive months
+ */
+export class Comparison {
+    /**
+     * Worked minutes of the previous and the current timebook
+     */
+    "PreviousTotalMins": number;
+    "CurrentTotalMins": number;
+    "DeltaTotalMins": number;
+
+    /**
+     * Changes per task short and per category, in the order of the taxonomy
+     * Only task shorts and categories logged in either timebook are included.
+     */
+    "Entries": ComparisonEntry[];
+    "CategoryEntries": CategoryComparisonEntry[];
+
+    /**
+     * Task shorts logged in the current timebook only
+     */
+    "NewTaskShorts": TaskShort[];
+
+    /**
+     * Task shorts logged in the previous timebook only
+     */
+    "DisappearedTaskShorts": TaskShort[];
+
+    /** Creates a new Comparison instance. */
+    constructor($$source: Partial<Comparison> = {}) {
+        if (!("PreviousTotalMins" in $$source)) {
+            this["PreviousTotalMins"] = 0;
+        }
+        if (!("CurrentTotalMins" in $$source)) {
+            this["CurrentTotalMins"] = 0;
+        }
+        if (!("DeltaTotalMins" in $$source)) {
+            this["DeltaTotalMins"] = 0;
+        }
+        if (!("Entries" in $$source)) {
+            this["Entries"] = [];
+        }
+        if (!("CategoryEntries" in $$source)) {
+            this["CategoryEntries"] = [];
+        }
+        if (!("NewTaskShorts" in $$source)) {
+            this["NewTaskShorts"] = [];
+        }
+        if (!("DisappearedTaskShorts" in $$source)) {
+            this["DisappearedTaskShorts"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Comparison instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Comparison {
+        const $$createField3_0 = $$createType3;
+        const $$createField4_0 = $$createType5;
+        const $$createField5_0 = $$createType1;
+        const $$createField6_0 = $$createType1;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Entries" in $$parsedSource) {
+            $$parsedSource["Entries"] = $$createField3_0($$parsedSource["Entries"]);
+        }
+        if ("CategoryEntries" in $$parsedSource) {
+            $$parsedSource["CategoryEntries"] = $$createField4_0($$parsedSource["CategoryEntries"]);
+        }
+        if ("NewTaskShorts" in $$parsedSource) {
+            $$parsedSource["NewTaskShorts"] = $$createField5_0($$parsedSource["NewTaskShorts"]);
+        }
+        if ("DisappearedTaskShorts" in $$parsedSource) {
+            $$parsedSource["DisappearedTaskShorts"] = $$createField6_0($$parsedSource["DisappearedTaskShorts"]);
+        }
+        return new Comparison($$parsedSource as Partial<Comparison>);
+    }
+}
+
+/**
+ * Change of a single task short between two timebooks
+ */
+export class ComparisonEntry {
+    /**
+     * The task short code (e.g. "A" for planned work)
+     */
+    "TaskShort": TaskShort;
+
+    /**
+     * The full name of the task (e.g. "Planned Work")
+     */
+    "TaskName": string;
+
+    /**
+     * The category short code (e.g. "M" for meetings)
+     */
+    "CategoryShort": CategoryShort;
+
+    /**
+     * Whether the task is a break or other non-working time
+     * If set, all factors are zero.
+     */
+    "NonWorking": boolean;
+
+    /**
+     * Minutes received in the previous and the current timebook
+     */
+    "PreviousMinutes": number;
+    "CurrentMinutes": number;
+    "DeltaMinutes": number;
+
+    /**
+     * Factors of received minutes to total minutes and their difference
+     * NOTE: These are factors between 0 and 1, not percentages.
+     */
+    "PreviousFactorOfTotal": number;
+    "CurrentFactorOfTotal": number;
+    "DeltaFactorOfTotal": number;
+
+    /**
+     * Whether the task short was logged in the current or the previous timebook only
+     */
+    "New": boolean;
+    "Disappeared": boolean;
+
+    /** Creates a new ComparisonEntry instance. */
+    constructor($$source: Partial<ComparisonEntry> = {}) {
+        if (!("TaskShort" in $$source)) {
+            this["TaskShort"] = TaskShort.$zero;
+        }
+        if (!("TaskName" in $$source)) {
+            this["TaskName"] = "";
+        }
+        if (!("CategoryShort" in $$source)) {
+            this["CategoryShort"] = CategoryShort.$zero;
+        }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
+        if (!("PreviousMinutes" in $$source)) {
+            this["PreviousMinutes"] = 0;
+        }
+        if (!("CurrentMinutes" in $$source)) {
+            this["CurrentMinutes"] = 0;
+        }
+        if (!("DeltaMinutes" in $$source)) {
+            this["DeltaMinutes"] = 0;
+        }
+        if (!("PreviousFactorOfTotal" in $$source)) {
+            this["PreviousFactorOfTotal"] = 0;
+        }
+        if (!("CurrentFactorOfTotal" in $$source)) {
+            this["CurrentFactorOfTotal"] = 0;
+        }
+        if (!("DeltaFactorOfTotal" in $$source)) {
+            this["DeltaFactorOfTotal"] = 0;
+        }
+        if (!("New" in $$source)) {
+            this["New"] = false;
+        }
+        if (!("Disappeared" in $$source)) {
+            this["Disappeared"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ComparisonEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ComparisonEntry {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ComparisonEntry($$parsedSource as Partial<ComparisonEntry>);
+    }
+}
+
+/**
+ * A problem found while parsing a line of the timebook
+ */
+export class Diagnostic {
+    /**
+     * Path of the timebook file
+     */
+    "FilePath": string;
+
+    /**
+     * 1-based line number within the timebook file
+     */
+    "Line": number;
+
+    /**
+     * 1-based column within the line, zero if unknown
+     */
+    "Column": number;
+    "Severity": Severity;
+    "Message": string;
+
+    /**
+     * The original line as written in the timebook
+     */
+    "Text": string;
+
+    /** Creates a new Diagnostic instance. */
+    constructor($$source: Partial<Diagnostic> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Line" in $$source)) {
+            this["Line"] = 0;
+        }
+        if (!("Column" in $$source)) {
+            this["Column"] = 0;
+        }
+        if (!("Severity" in $$source)) {
+            this["Severity"] = Severity.$zero;
+        }
+        if (!("Message" in $$source)) {
+            this["Message"] = "";
+        }
+        if (!("Text" in $$source)) {
+            this["Text"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Diagnostic instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Diagnostic {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Diagnostic($$parsedSource as Partial<Diagnostic>);
+    }
+}
+
+/**
+ * Summary of a single file of a timebook, that may span multiple files
+ */
+export class FileSummary {
+    "FilePath": string;
+
+    /**
+     * Information about the file, taken from its front matter
+     */
+    "Metadata": Metadata;
+
+    /**
+     * First and last day of the dated tasks within the file, zero if none
+     */
+    "Start": time$0.Time;
+    "End": time$0.Time;
+    "Entries": SummaryEntry[];
+    "TotalMins": number;
+    "NonWorkingMins": number;
+    "InProgressMins": number;
+
+    /**
+     * Number of problems found while parsing the file
+     */
+    "CountDiagnostics": number;
+
+    /** Creates a new FileSummary instance. */
+    constructor($$source: Partial<FileSummary> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Metadata" in $$source)) {
+            this["Metadata"] = (new Metadata());
+        }
+        if (!("Start" in $$source)) {
+            this["Start"] = null;
+        }
+        if (!("End" in $$source)) {
+            this["End"] = null;
+        }
+        if (!("Entries" in $$source)) {
+            this["Entries"] = [];
+        }
+        if (!("TotalMins" in $$source)) {
+            this["TotalMins"] = 0;
+        }
+        if (!("NonWorkingMins" in $$source)) {
+            this["NonWorkingMins"] = 0;
+        }
+        if (!("InProgressMins" in $$source)) {
+            this["InProgressMins"] = 0;
+        }
+        if (!("CountDiagnostics" in $$source)) {
+            this["CountDiagnostics"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new FileSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): FileSummary {
+        const $$createField1_0 = $$createType6;
+        const $$createField4_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Metadata" in $$parsedSource) {
+            $$parsedSource["Metadata"] = $$createField1_0($$parsedSource["Metadata"]);
+        }
+        if ("Entries" in $$parsedSource) {
+            $$parsedSource["Entries"] = $$createField4_0($$parsedSource["Entries"]);
+        }
+        return new FileSummary($$parsedSource as Partial<FileSummary>);
+    }
+}
+
+/**
+ * A time range within a day, that is not covered by any task
+ */
+export class Gap {
+    /**
+     * Path of the file logging the tasks around the gap
+     */
+    "FilePath": string;
+
+    /**
+     * The day of the gap
+     */
+    "Date": time$0.Time;
+
+    /**
+     * 1-based line numbers of the tasks before and after the gap
+     */
+    "LineBefore": number;
+    "LineAfter": number;
+
+    /**
+     * The range of the gap, normalized to "HH:MM"
+     */
+    "StartTime": string;
+    "EndTime": string;
+
+    /**
+     * Duration of the gap in minutes
+     */
+    "DurationMins": number;
+
+    /**
+     * Minutes of the gap not covered by the lunch break allowance
+     */
+    "UnloggedMins": number;
+
+    /** Creates a new Gap instance. */
+    constructor($$source: Partial<Gap> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = null;
+        }
+        if (!("LineBefore" in $$source)) {
+            this["LineBefore"] = 0;
+        }
+        if (!("LineAfter" in $$source)) {
+            this["LineAfter"] = 0;
+        }
+        if (!("StartTime" in $$source)) {
+            this["StartTime"] = "";
+        }
+        if (!("EndTime" in $$source)) {
+            this["EndTime"] = "";
+        }
+        if (!("DurationMins" in $$source)) {
+            this["DurationMins"] = 0;
+        }
+        if (!("UnloggedMins" in $$source)) {
+            this["UnloggedMins"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Gap instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Gap {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Gap($$parsedSource as Partial<Gap>);
+    }
+}
+
+/**
+ * A summary entry for a single ticket or tag
+ */
+export class LabelSummaryEntry {
+    /**
+     * The ticket reference (e.g. "JIRA-123") or tag (e.g. "backend")
+     */
+    "Label": string;
+
+    /**
+     * Number of tasks referencing this label
+     */
+    "CountTasks": number;
+
+    /**
+     * Minutes received by all tasks referencing this label
+     */
+    "ReceivedMinutes": number;
+
+    /**
+     * Factor of received minutes to total minutes
+     * NOTE: A task may reference multiple labels, so the factors of all
+     * labels may add up to more than 1.
+     */
+    "FactorOfTotal": number;
+
+    /** Creates a new LabelSummaryEntry instance. */
+    constructor($$source: Partial<LabelSummaryEntry> = {}) {
+        if (!("Label" in $$source)) {
+            this["Label"] = "";
+        }
+        if (!("CountTasks" in $$source)) {
+            this["CountTasks"] = 0;
+        }
+        if (!("ReceivedMinutes" in $$source)) {
+            this["ReceivedMinutes"] = 0;
+        }
+        if (!("FactorOfTotal" in $$source)) {
+            this["FactorOfTotal"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new LabelSummaryEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): LabelSummaryEntry {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new LabelSummaryEntry($$parsedSource as Partial<LabelSummaryEntry>);
+    }
+}
+
+/**
+ * Language of task and category names
+ */
+export enum Language {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    LanguageGerman = "de",
+    LanguageEnglish = "en",
+
+    /**
+     * Language used if none is configured
+     */
+    defaultLanguage = "de",
+};
+
+/**
+ * Information about a timebook, read from its YAML front matter
+ */
+export class Metadata {
+    /**
+     * The person the timebook belongs to
+     */
+    "Person": string;
+
+    /**
+     * First and last day the timebook covers, zero if unknown
+     */
+    "PeriodStart": time$0.Time;
+    "PeriodEnd": time$0.Time;
+
+    /**
+     * Contracted working hours per week, zero if unknown
+     */
+    "WeeklyHours": number;
+
+    /**
+     * Language of task and category names, if none is chosen in the settings
+     */
+    "Locale": Language;
+
+    /**
+     * Task and category definitions merged into the configured taxonomy
+     */
+    "Taxonomy": Taxonomy | null;
+
+    /** Creates a new Metadata instance. */
+    constructor($$source: Partial<Metadata> = {}) {
+        if (!("Person" in $$source)) {
+            this["Person"] = "";
+        }
+        if (!("PeriodStart" in $$source)) {
+            this["PeriodStart"] = null;
+        }
+        if (!("PeriodEnd" in $$source)) {
+            this["PeriodEnd"] = null;
+        }
+        if (!("WeeklyHours" in $$source)) {
+            this["WeeklyHours"] = 0;
+        }
+        if (!("Locale" in $$source)) {
+            this["Locale"] = Language.$zero;
+        }
+        if (!("Taxonomy" in $$source)) {
+            this["Taxonomy"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Metadata instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Metadata {
+        const $$createField5_0 = $$createType10;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Taxonomy" in $$parsedSource) {
+            $$parsedSource["Taxonomy"] = $$createField5_0($$parsedSource["Taxonomy"]);
+        }
+        return new Metadata($$parsedSource as Partial<Metadata>);
+    }
+}
+
+/**
+ * A time range logged by two tasks
+ */
+export class Overlap {
+    /**
+     * Path of the file logging both tasks
+     */
+    "FilePath": string;
+
+    /**
+     * The day of the later task
+     */
+    "Date": time$0.Time;
+
+    /**
+     * 1-based line numbers of the later and the earlier task
+     */
+    "Line": number;
+    "OtherLine": number;
+
+    /**
+     * The overlapping range, normalized to "HH:MM"
+     */
+    "StartTime": string;
+    "EndTime": string;
+
+    /**
+     * Duration of the overlapping range in minutes
+     */
+    "DurationMins": number;
+
+    /** Creates a new Overlap instance. */
+    constructor($$source: Partial<Overlap> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = null;
+        }
+        if (!("Line" in $$source)) {
+            this["Line"] = 0;
+        }
+        if (!("OtherLine" in $$source)) {
+            this["OtherLine"] = 0;
+        }
+        if (!("StartTime" in $$source)) {
+            this["StartTime"] = "";
+        }
+        if (!("EndTime" in $$source)) {
+            this["EndTime"] = "";
+        }
+        if (!("DurationMins" in $$source)) {
+            this["DurationMins"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Overlap instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Overlap {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Overlap($$parsedSource as Partial<Overlap>);
+    }
+}
+
+/**
+ * A period of time to group timebook entries by
+ */
+export enum Period {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    PeriodDay = "day",
+    PeriodWeek = "week",
+    PeriodMonth = "month",
+};
+
+/**
+ * Summary of timebook entries within a single period
+ * NOTE: Expectations are taken from the files and scopes, whose dated tasks lie
+ * within the period. Expectations of parts spanning multiple periods are left out.
+ * NOTE: FactorOfTotal of the entries is calculated over the period only.
+ */
+export class PeriodSummary {
+    /**
+     * The key of the period (e.g. "2025-10-09", "2025-W41" or "2025-10")
+     */
+    "Period": string;
+
+    /**
+     * First and last day of the period
+     */
+    "Start": time$0.Time;
+    "End": time$0.Time;
+    "Entries": SummaryEntry[];
+    "TotalMins": number;
+    "NonWorkingMins": number;
+
+    /** Creates a new PeriodSummary instance. */
+    constructor($$source: Partial<PeriodSummary> = {}) {
+        if (!("Period" in $$source)) {
+            this["Period"] = "";
+        }
+        if (!("Start" in $$source)) {
+            this["Start"] = null;
+        }
+        if (!("End" in $$source)) {
+            this["End"] = null;
+        }
+        if (!("Entries" in $$source)) {
+            this["Entries"] = [];
+        }
+        if (!("TotalMins" in $$source)) {
+            this["TotalMins"] = 0;
+        }
+        if (!("NonWorkingMins" in $$source)) {
+            this["NonWorkingMins"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PeriodSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): PeriodSummary {
+        const $$createField3_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Entries" in $$parsedSource) {
+            $$parsedSource["Entries"] = $$createField3_0($$parsedSource["Entries"]);
+        }
+        return new PeriodSummary($$parsedSource as Partial<PeriodSummary>);
+    }
+}
+
+/**
+ * Summary of the tasks within a section, that has expectations of its own
+ */
+export class ScopeSummary {
+    /**
+     * Path of the file containing the section
+     */
+    "FilePath": string;
+
+    /**
+     * Text of the heading opening the section (e.g. "Week 41")
+     */
+    "Heading": string;
+
+    /**
+     * 1-based line number of the heading
+     */
+    "Line": number;
+
+    /**
+     * 1-based line number of the heading of the enclosing scope, zero if there is none
+     * Enclosing sections always have expectations, at least rolled up ones.
+     */
+    "ParentLine": number;
+
+    /**
+     * First and last day of the dated tasks within the section, zero if none
+     */
+    "Start": time$0.Time;
+    "End": time$0.Time;
+    "Entries": SummaryEntry[];
+    "TotalMins": number;
+    "NonWorkingMins": number;
+
+    /** Creates a new ScopeSummary instance. */
+    constructor($$source: Partial<ScopeSummary> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Heading" in $$source)) {
+            this["Heading"] = "";
+        }
+        if (!("Line" in $$source)) {
+            this["Line"] = 0;
+        }
+        if (!("ParentLine" in $$source)) {
+            this["ParentLine"] = 0;
+        }
+        if (!("Start" in $$source)) {
+            this["Start"] = null;
+        }
+        if (!("End" in $$source)) {
+            this["End"] = null;
+        }
+        if (!("Entries" in $$source)) {
+            this["Entries"] = [];
+        }
+        if (!("TotalMins" in $$source)) {
+            this["TotalMins"] = 0;
+        }
+        if (!("NonWorkingMins" in $$source)) {
+            this["NonWorkingMins"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ScopeSummary instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ScopeSummary {
+        const $$createField6_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Entries" in $$parsedSource) {
+            $$parsedSource["Entries"] = $$createField6_0($$parsedSource["Entries"]);
+        }
+        return new ScopeSummary($$parsedSource as Partial<ScopeSummary>);
+    }
+}
+
+/**
+ * User settings, persisted in the user config directory
+ */
+export class Settings {
+    /**
+     * Language of task and category names
+     * If empty, the default language is used.
+     */
+    "Language": Language;
+
+    /**
+     * Whether time logged by overlapping tasks counts once only in TotalMins
+     * NOTE: Received minutes of single entries still include overlaps.
+     */
+    "CountWallClockOnly": boolean;
+
+    /**
+     * Shortest gap between tasks to report, in minutes
+     * If zero, a default of 15 minutes is used.
+     */
+    "MinGapMins": number;
+
+    /**
+     * Minutes of the lunch gap per day, that are allowed as lunch break
+     */
+    "LunchBreakMins": number;
+
+    /** Creates a new Settings instance. */
+    constructor($$source: Partial<Settings> = {}) {
+        if (!("Language" in $$source)) {
+            this["Language"] = Language.$zero;
+        }
+        if (!("CountWallClockOnly" in $$source)) {
+            this["CountWallClockOnly"] = false;
+        }
+        if (!("MinGapMins" in $$source)) {
+            this["MinGapMins"] = 0;
+        }
+        if (!("LunchBreakMins" in $$source)) {
+            this["LunchBreakMins"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Settings instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Settings {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Settings($$parsedSource as Partial<Settings>);
+    }
+}
+
+/**
+ * Severity of a diagnostic
+ */
+export enum Severity {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * The line was skipped and is missing in the summary
+     */
+    SeverityError = "error",
+
+    /**
+     * The line was skipped, but may not be meant as timebook content
+     */
+    SeverityWarning = "warning",
+};
+
+/**
+ * A key to sort summary entries by
+ */
+export enum SortKey {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    SortByTaxonomy = "taxonomy",
+    SortByReceivedMinutes = "receivedMinutes",
+    SortByExpectedMinutes = "expectedMinutes",
+    SortByFactorOfExpected = "factorOfExpected",
+    SortByFactorOfTotal = "factorOfTotal",
+    SortByName = "name",
 };
 
 /**
@@ -28,6 +1116,12 @@ export class SummaryEntry {
      */
     "TaskShort": TaskShort;
 
+    /**
+     * The sub type of a two-level task short (e.g. "m" for "Mm")
+     * Empty for entries summing up the whole task short.
+     */
+    "SubType": string;
+
     /**
      * The full name of the task (e.g. "Planned Work")
      */
@@ -43,6 +1137,12 @@ export class SummaryEntry {
      */
     "CategoryName": string;
 
+    /**
+     * Whether the task is a break or other non-working time
+     * If set, FactorOfTotal is always zero.
+     */
+    "NonWorking": boolean;
+
     /**
      * Number of tasks for this entry
      */
@@ -73,11 +1173,20 @@ export class SummaryEntry {
      */
     "FactorOfTotal": number;
 
+    /**
+     * Entries per sub type of this task short, ordered by sub type
+     * NOTE: Sub entries are already included in the numbers of this entry.
+     */
+    "SubEntries": SummaryEntry[];
+
     /** Creates a new SummaryEntry instance. */
     constructor($$source: Partial<SummaryEntry> = {}) {
         if (!("TaskShort" in $$source)) {
             this["TaskShort"] = TaskShort.$zero;
         }
+        if (!("SubType" in $$source)) {
+            this["SubType"] = "";
+        }
         if (!("TaskName" in $$source)) {
             this["TaskName"] = "";
         }
@@ -87,6 +1196,9 @@ export class SummaryEntry {
         if (!("CategoryName" in $$source)) {
             this["CategoryName"] = "";
         }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
         if (!("CountTasks" in $$source)) {
             this["CountTasks"] = 0;
         }
@@ -102,6 +1214,9 @@ export class SummaryEntry {
         if (!("FactorOfTotal" in $$source)) {
             this["FactorOfTotal"] = 0;
         }
+        if (!("SubEntries" in $$source)) {
+            this["SubEntries"] = [];
+        }
 
         Object.assign(this, $$source);
     }
@@ -110,11 +1225,251 @@ export class SummaryEntry {
      * Creates a new SummaryEntry instance from a string or object.
      */
     static createFrom($$source: any = {}): SummaryEntry {
+        const $$createField11_0 = $$createType8;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("SubEntries" in $$parsedSource) {
+            $$parsedSource["SubEntries"] = $$createField11_0($$parsedSource["SubEntries"]);
+        }
         return new SummaryEntry($$parsedSource as Partial<SummaryEntry>);
     }
 }
 
+/**
+ * Definition of a single task short
+ */
+export class TaskDefinition {
+    /**
+     * Single letter task short (e.g. "R" for reviews)
+     */
+    "Short": TaskShort;
+
+    /**
+     * The full name of the task (e.g. "Reviews")
+     */
+    "Name": string;
+
+    /**
+     * The full names of the task per language, preferred over Name
+     */
+    "Names": { [_: Language]: string };
+
+    /**
+     * The category the task belongs to
+     */
+    "Category": CategoryShort;
+
+    /** Creates a new TaskDefinition instance. */
+    constructor($$source: Partial<TaskDefinition> = {}) {
+        if (!("Short" in $$source)) {
+            this["Short"] = TaskShort.$zero;
+        }
+        if (!("Name" in $$source)) {
+            this["Name"] = "";
+        }
+        if (!("Names" in $$source)) {
+            this["Names"] = {};
+        }
+        if (!("Category" in $$source)) {
+            this["Category"] = CategoryShort.$zero;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TaskDefinition instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TaskDefinition {
+        const $$createField2_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Names" in $$parsedSource) {
+            $$parsedSource["Names"] = $$createField2_0($$parsedSource["Names"]);
+        }
+        return new TaskDefinition($$parsedSource as Partial<TaskDefinition>);
+    }
+}
+
+/**
+ * A single task as logged in the timebook
+ */
+export class TaskEntry {
+    /**
+     * Path of the timebook file
+     */
+    "FilePath": string;
+
+    /**
+     * 1-based line number within the timebook file
+     */
+    "Line": number;
+
+    /**
+     * The task short code (e.g. "A" for planned work)
+     */
+    "TaskShort": TaskShort;
+
+    /**
+     * The sub type of a two-level task short (e.g. "m" for "Mm")
+     */
+    "SubType": string;
+
+    /**
+     * The day of the task, taken from the closest preceding date heading
+     * If no date heading precedes the task, this is the zero time.
+     */
+    "Date": time$0.Time;
+
+    /**
+     * Start and end time normalized to "HH:MM" (e.g. "09:00")
+     * Running tasks have no end time.
+     */
+    "StartTime": string;
+    "EndTime": string;
+
+    /**
+     * Start and end as minutes since midnight
+     */
+    "StartMins": number;
+    "EndMins": number;
+
+    /**
+     * Whether the task has no end yet and is still running
+     */
+    "Running": boolean;
+
+    /**
+     * Whether the task was logged with a duration only, without start and end
+     * Such tasks are counted, but not placed on a timeline.
+     */
+    "DurationOnly": boolean;
+
+    /**
+     * Whether the task ends on the day after its start
+     */
+    "EndsNextDay": boolean;
+
+    /**
+     * Whether the task is a break or other non-working time
+     * Such tasks are not part of worked totals and factors.
+     */
+    "NonWorking": boolean;
+
+    /**
+     * Duration of the task in minutes
+     * NOTE: The time of child tasks is already subtracted.
+     */
+    "DurationMins": number;
+
+    /**
+     * 1-based line number of the enclosing task, zero for top-level tasks
+     */
+    "ParentLine": number;
+
+    /**
+     * Free text after the time range
+     * Child tasks without description inherit the one of their parent.
+     */
+    "Description": string;
+
+    /**
+     * Ticket references found in the description (e.g. "JIRA-123")
+     */
+    "Tickets": string[];
+
+    /**
+     * Tags found in the description, without leading "#" (e.g. "backend")
+     */
+    "Tags": string[];
+
+    /**
+     * Text of list items nested below the task, that are no tasks themselves
+     */
+    "Notes": string[];
+
+    /** Creates a new TaskEntry instance. */
+    constructor($$source: Partial<TaskEntry> = {}) {
+        if (!("FilePath" in $$source)) {
+            this["FilePath"] = "";
+        }
+        if (!("Line" in $$source)) {
+            this["Line"] = 0;
+        }
+        if (!("TaskShort" in $$source)) {
+            this["TaskShort"] = TaskShort.$zero;
+        }
+        if (!("SubType" in $$source)) {
+            this["SubType"] = "";
+        }
+        if (!("Date" in $$source)) {
+            this["Date"] = null;
+        }
+        if (!("StartTime" in $$source)) {
+            this["StartTime"] = "";
+        }
+        if (!("EndTime" in $$source)) {
+            this["EndTime"] = "";
+        }
+        if (!("StartMins" in $$source)) {
+            this["StartMins"] = 0;
+        }
+        if (!("EndMins" in $$source)) {
+            this["EndMins"] = 0;
+        }
+        if (!("Running" in $$source)) {
+            this["Running"] = false;
+        }
+        if (!("DurationOnly" in $$source)) {
+            this["DurationOnly"] = false;
+        }
+        if (!("EndsNextDay" in $$source)) {
+            this["EndsNextDay"] = false;
+        }
+        if (!("NonWorking" in $$source)) {
+            this["NonWorking"] = false;
+        }
+        if (!("DurationMins" in $$source)) {
+            this["DurationMins"] = 0;
+        }
+        if (!("ParentLine" in $$source)) {
+            this["ParentLine"] = 0;
+        }
+        if (!("Description" in $$source)) {
+            this["Description"] = "";
+        }
+        if (!("Tickets" in $$source)) {
+            this["Tickets"] = [];
+        }
+        if (!("Tags" in $$source)) {
+            this["Tags"] = [];
+        }
+        if (!("Notes" in $$source)) {
+            this["Notes"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new TaskEntry instance from a string or object.
+     */
+    static createFrom($$source: any = {}): TaskEntry {
+        const $$createField16_0 = $$createType11;
+        const $$createField17_0 = $$createType11;
+        const $$createField18_0 = $$createType11;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Tickets" in $$parsedSource) {
+            $$parsedSource["Tickets"] = $$createField16_0($$parsedSource["Tickets"]);
+        }
+        if ("Tags" in $$parsedSource) {
+            $$parsedSource["Tags"] = $$createField17_0($$parsedSource["Tags"]);
+        }
+        if ("Notes" in $$parsedSource) {
+            $$parsedSource["Notes"] = $$createField18_0($$parsedSource["Notes"]);
+        }
+        return new TaskEntry($$parsedSource as Partial<TaskEntry>);
+    }
+}
+
 /**
  * A task short code (e.g. "A" for planned work)
  */
@@ -131,15 +1486,112 @@ export enum TaskShort {
     Support = "S",
     Maintenance = "W",
     Miscellaneous = "V",
+    Break = "P",
 };
 
+/**
+ * Mapping of task shorts to their names and categories
+ */
+export class Taxonomy {
+    "Tasks": TaskDefinition[];
+    "Categories": CategoryDefinition[];
+
+    /**
+     * Task short used for unknown task shorts
+     */
+    "FallbackTaskShort": TaskShort;
+
+    /** Creates a new Taxonomy instance. */
+    constructor($$source: Partial<Taxonomy> = {}) {
+        if (!("Tasks" in $$source)) {
+            this["Tasks"] = [];
+        }
+        if (!("Categories" in $$source)) {
+            this["Categories"] = [];
+        }
+        if (!("FallbackTaskShort" in $$source)) {
+            this["FallbackTaskShort"] = TaskShort.$zero;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Taxonomy instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Taxonomy {
+        const $$createField0_0 = $$createType13;
+        const $$createField1_0 = $$createType15;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("Tasks" in $$parsedSource) {
+            $$parsedSource["Tasks"] = $$createField0_0($$parsedSource["Tasks"]);
+        }
+        if ("Categories" in $$parsedSource) {
+            $$parsedSource["Categories"] = $$createField1_0($$parsedSource["Categories"]);
+        }
+        return new Taxonomy($$parsedSource as Partial<Taxonomy>);
+    }
+}
+
 /**
  * Summary of timebook entries including total minutes
  */
 export class TimebookSummary {
     "Entries": SummaryEntry[];
+
+    /**
+     * Minutes worked, without breaks and other non-working time
+     */
     "TotalMins": number;
 
+    /**
+     * Minutes of breaks and other non-working time
+     */
+    "NonWorkingMins": number;
+
+    /**
+     * All finished tasks of the timebook in order of appearance
+     */
+    "Tasks": TaskEntry[];
+
+    /**
+     * Tasks that are still running, with their duration until now
+     * NOTE: These are not part of Entries, TotalMins or Tasks.
+     */
+    "InProgress": TaskEntry[];
+
+    /**
+     * Minutes of running tasks started today, without running breaks
+     * NOTE: Tasks of past days are still listed, as they were probably not ended.
+     */
+    "InProgressMins": number;
+
+    /**
+     * Problems found while parsing, in order of appearance
+     */
+    "Diagnostics": Diagnostic[];
+
+    /**
+     * Time ranges logged by more than one task
+     */
+    "Overlaps": Overlap[];
+
+    /**
+     * Information about the timebook, taken from its front matter
+     */
+    "Metadata": Metadata;
+
+    /**
+     * Summaries of sections with expectations of their own, in order of appearance
+     * NOTE: Entries and TotalMins above include all expectations and tasks.
+     */
+    "Scopes": ScopeSummary[];
+
+    /**
+     * Summaries of the files the timebook was loaded from, in order of loading
+     */
+    "Files": FileSummary[];
+
     /** Creates a new TimebookSummary instance. */
     constructor($$source: Partial<TimebookSummary> = {}) {
         if (!("Entries" in $$source)) {
@@ -148,6 +1600,33 @@ export class TimebookSummary {
         if (!("TotalMins" in $$source)) {
             this["TotalMins"] = 0;
         }
+        if (!("NonWorkingMins" in $$source)) {
+            this["NonWorkingMins"] = 0;
+        }
+        if (!("Tasks" in $$source)) {
+            this["Tasks"] = [];
+        }
+        if (!("InProgress" in $$source)) {
+            this["InProgress"] = [];
+        }
+        if (!("InProgressMins" in $$source)) {
+            this["InProgressMins"] = 0;
+        }
+        if (!("Diagnostics" in $$source)) {
+            this["Diagnostics"] = [];
+        }
+        if (!("Overlaps" in $$source)) {
+            this["Overlaps"] = [];
+        }
+        if (!("Metadata" in $$source)) {
+            this["Metadata"] = (new Metadata());
+        }
+        if (!("Scopes" in $$source)) {
+            this["Scopes"] = [];
+        }
+        if (!("Files" in $$source)) {
+            this["Files"] = [];
+        }
 
         Object.assign(this, $$source);
     }
@@ -156,15 +1635,67 @@ export class TimebookSummary {
      * Creates a new TimebookSummary instance from a string or object.
      */
     static createFrom($$source: any = {}): TimebookSummary {
-        const $$createField0_0 = $$createType1;
+        const $$createField0_0 = $$createType8;
+        const $$createField3_0 = $$createType17;
+        const $$createField4_0 = $$createType17;
+        const $$createField6_0 = $$createType19;
+        const $$createField7_0 = $$createType21;
+        const $$createField8_0 = $$createType6;
+        const $$createField9_0 = $$createType23;
+        const $$createField10_0 = $$createType25;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
         if ("Entries" in $$parsedSource) {
             $$parsedSource["Entries"] = $$createField0_0($$parsedSource["Entries"]);
         }
+        if ("Tasks" in $$parsedSource) {
+            $$parsedSource["Tasks"] = $$createField3_0($$parsedSource["Tasks"]);
+        }
+        if ("InProgress" in $$parsedSource) {
+            $$parsedSource["InProgress"] = $$createField4_0($$parsedSource["InProgress"]);
+        }
+        if ("Diagnostics" in $$parsedSource) {
+            $$parsedSource["Diagnostics"] = $$createField6_0($$parsedSource["Diagnostics"]);
+        }
+        if ("Overlaps" in $$parsedSource) {
+            $$parsedSource["Overlaps"] = $$createField7_0($$parsedSource["Overlaps"]);
+        }
+        if ("Metadata" in $$parsedSource) {
+            $$parsedSource["Metadata"] = $$createField8_0($$parsedSource["Metadata"]);
+        }
+        if ("Scopes" in $$parsedSource) {
+            $$parsedSource["Scopes"] = $$createField9_0($$parsedSource["Scopes"]);
+        }
+        if ("Files" in $$parsedSource) {
+            $$parsedSource["Files"] = $$createField10_0($$parsedSource["Files"]);
+        }
         return new TimebookSummary($$parsedSource as Partial<TimebookSummary>);
     }
 }
 
 // Private type creation functions
-const $$createType0 = SummaryEntry.createFrom;
-const $$createType1 = $Create.Array($$createType0);
+const $$createType0 = $Create.Map($Create.Any, $Create.Any);
+const $$createType1 = $Create.Array($Create.Any);
+const $$createType2 = ComparisonEntry.createFrom;
+const $$createType3 = $Create.Array($$createType2);
+const $$createType4 = CategoryComparisonEntry.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = Metadata.createFrom;
+const $$createType7 = SummaryEntry.createFrom;
+const $$createType8 = $Create.Array($$createType7);
+const $$createType9 = Taxonomy.createFrom;
+const $$createType10 = $Create.Nullable($$createType9);
+const $$createType11 = $Create.Array($Create.Any);
+const $$createType12 = TaskDefinition.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = CategoryDefinition.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = TaskEntry.createFrom;
+const $$createType17 = $Create.Array($$createType16);
+const $$createType18 = Diagnostic.createFrom;
+const $$createType19 = $Create.Array($$createType18);
+const $$createType20 = Overlap.createFrom;
+const $$createType21 = $Create.Array($$createType20);
+const $$createType22 = ScopeSummary.createFrom;
+const $$createType23 = $Create.Array($$createType22);
+const $$createType24 = FileSummary.createFrom;
+const $$createType25 = $Create.Array($$createType24);
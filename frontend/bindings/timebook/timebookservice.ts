@@ -9,15 +9,180 @@ import { Call as $Call, CancellablePromise as $CancellablePromise, Create as $Cr
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * Compare a previous timebook with the current one, e.g. September with October
+ * Both summaries are taken as given, so they may come from single files or
+ * from whole directories.
+ */
+export function Compare(previous: $models.TimebookSummary, current: $models.TimebookSummary): $CancellablePromise<$models.Comparison> {
+    return $Call.ByID(1861479616, previous, current).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * Compare a timebook file with the last loaded timebook
+ * The file is taken as the previous one, the last loaded timebook stays loaded.
+ */
+export function CompareWithFile(previousFilePath: string): $CancellablePromise<$models.Comparison> {
+    return $Call.ByID(1607317838, previousFilePath).then(($result: any) => {
+        return $$createType0($result);
+    });
+}
+
+/**
+ * Rewrite a timebook file into its canonical form
+ * Returns whether the file changed, it has to be loaded again to see the changes.
+ */
+export function FormatFile(filePath: string): $CancellablePromise<boolean> {
+    return $Call.ByID(4122721928, filePath);
+}
+
+/**
+ * Sum up the last loaded timebook per category
+ */
+export function GetCategorySummary(): $CancellablePromise<$models.CategorySummaryEntry[]> {
+    return $Call.ByID(2500025857).then(($result: any) => {
+        return $$createType2($result);
+    });
+}
+
+/**
+ * Find the time ranges between tasks of the last loaded timebook, that are not logged
+ * The lunch gap of each day is reduced by the configured lunch break allowance.
+ * Gaps are searched per file, as tasks of different files do not follow each other.
+ */
+export function GetGaps(): $CancellablePromise<$models.Gap[]> {
+    return $Call.ByID(2369768386).then(($result: any) => {
+        return $$createType4($result);
+    });
+}
+
+/**
+ * Get the language of task and category names
+ */
+export function GetLanguage(): $CancellablePromise<$models.Language> {
+    return $Call.ByID(1708649781);
+}
+
+/**
+ * Get all languages available for task and category names
+ */
+export function GetLanguages(): $CancellablePromise<$models.Language[]> {
+    return $Call.ByID(2565506354).then(($result: any) => {
+        return $$createType5($result);
+    });
+}
+
+/**
+ * Get the current user settings
+ */
+export function GetSettings(): $CancellablePromise<$models.Settings> {
+    return $Call.ByID(4275345998).then(($result: any) => {
+        return $$createType6($result);
+    });
+}
+
+/**
+ * Get the entries of the last loaded timebook sorted by the given key
+ * Entries are in the order of the taxonomy by default, which also breaks ties.
+ */
+export function GetSortedEntries(key: $models.SortKey, descending: boolean): $CancellablePromise<$models.SummaryEntry[]> {
+    return $Call.ByID(3435092706, key, descending).then(($result: any) => {
+        return $$createType8($result);
+    });
+}
+
+/**
+ * Sum up the last loaded timebook per tag
+ */
+export function GetTagSummary(): $CancellablePromise<$models.LabelSummaryEntry[]> {
+    return $Call.ByID(1034376703).then(($result: any) => {
+        return $$createType10($result);
+    });
+}
+
+/**
+ * Sum up the last loaded timebook per referenced ticket
+ */
+export function GetTicketSummary(): $CancellablePromise<$models.LabelSummaryEntry[]> {
+    return $Call.ByID(1717336725).then(($result: any) => {
+        return $$createType10($result);
+    });
+}
+
+/**
+ * Load all timebook files of a directory, ordered by name
+ */
+export function LoadDirectory(dirPath: string): $CancellablePromise<$models.TimebookSummary> {
+    return $Call.ByID(713015664, dirPath).then(($result: any) => {
+        return $$createType11($result);
+    });
+}
+
 export function LoadFile(filePath: string): $CancellablePromise<$models.TimebookSummary> {
     return $Call.ByID(1022382219, filePath).then(($result: any) => {
-        return $$createType0($result);
+        return $$createType11($result);
     });
 }
 
+/**
+ * Load multiple timebook files and merge them into one summary
+ * Each file is parsed on its own, so expectations, sections, overlaps and
+ * gaps stay within their file. Entries and totals cover all files.
+ */
+export function LoadFiles(filePaths: string[]): $CancellablePromise<$models.TimebookSummary> {
+    return $Call.ByID(3863967336, filePaths).then(($result: any) => {
+        return $$createType11($result);
+    });
+}
+
+/**
+ * Change and persist the user settings
+ * Names of the last loaded timebook are relabeled, other changes apply to the next loaded one.
+ */
+export function SaveSettings(settings: $models.Settings): $CancellablePromise<void> {
+    return $Call.ByID(3115720361, settings);
+}
+
+export function SelectDirectory(): $CancellablePromise<string> {
+    return $Call.ByID(1536301412);
+}
+
 export function SelectFile(): $CancellablePromise<string> {
     return $Call.ByID(1570251951);
 }
 
+/**
+ * Change and persist the language of task and category names
+ * The last loaded timebook is relabeled accordingly.
+ */
+export function SetLanguage(language: $models.Language): $CancellablePromise<void> {
+    return $Call.ByID(2402650649, language);
+}
+
+/**
+ * Group the tasks of the last loaded timebook by day, ISO week or month
+ * Tasks without a date heading are not part of any period.
+ */
+export function SummarizeBy(period: $models.Period): $CancellablePromise<$models.PeriodSummary[]> {
+    return $Call.ByID(1924233415, period).then(($result: any) => {
+        return $$createType13($result);
+    });
+}
+
 // Private type creation functions
-const $$createType0 = $models.TimebookSummary.createFrom;
+const $$createType0 = $models.Comparison.createFrom;
+const $$createType1 = $models.CategorySummaryEntry.createFrom;
+const $$createType2 = $Create.Array($$createType1);
+const $$createType3 = $models.Gap.createFrom;
+const $$createType4 = $Create.Array($$createType3);
+const $$createType5 = $Create.Array($Create.Any);
+const $$createType6 = $models.Settings.createFrom;
+const $$createType7 = $models.SummaryEntry.createFrom;
+const $$createType8 = $Create.Array($$createType7);
+const $$createType9 = $models.LabelSummaryEntry.createFrom;
+const $$createType10 = $Create.Array($$createType9);
+const $$createType11 = $models.TimebookSummary.createFrom;
+const $$createType12 = $models.PeriodSummary.createFrom;
+const $$createType13 = $Create.Array($$createType12);
@@ -5,9 +5,9 @@ import "./CakeView.css";
 export function CakeView({ timebookSummary }: { timebookSummary: TimebookSummary }) {
     let lastStartAngle = 0;
 
-    const slices = timebookSummary.Entries.sort(
-        (a, b) => b.ReceivedMinutes - a.ReceivedMinutes,
-    ).map((entry, index, entries) => {
+    const workingEntries = timebookSummary.Entries.filter((entry) => !entry.NonWorking);
+
+    const slices = workingEntries.map((entry, index, entries) => {
         const hue = Math.round((index / entries.length) * 120); // 0 (red) to 120 (green)
 
         const startAngle = lastStartAngle;
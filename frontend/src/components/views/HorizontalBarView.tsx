@@ -1,19 +1,42 @@
+import { Fragment } from "react";
+
 import { TimebookSummary } from "../../../bindings/timebook";
 
 import "./HorizontalBarView.css";
 
 export function HorizontalBarView({ timebookSummary }: { timebookSummary: TimebookSummary }) {
-    const bars = timebookSummary.Entries.sort((a, b) => b.ReceivedMinutes - a.ReceivedMinutes).map(
-        (entry, _, entries) => {
-            const width = Math.round((entry.FactorOfTotal / entries[0].FactorOfTotal) * 100);
+    const workingEntries = timebookSummary.Entries.filter((entry) => !entry.NonWorking);
+    const maxFactorOfTotal = Math.max(...workingEntries.map((entry) => entry.FactorOfTotal));
+
+    const bars = workingEntries.map((entry) => {
+        const width = Math.round((entry.FactorOfTotal / maxFactorOfTotal) * 100);
+
+        const hue = 120 - Math.round((width / 100) * 120); // 0 (red) to 120 (green)
+        const durationHours = (entry.ReceivedMinutes / 60).toFixed(1);
+        const percentage = (entry.FactorOfTotal * 100).toFixed(0);
 
-            const hue = 120 - Math.round((width / 100) * 120); // 0 (red) to 120 (green)
-            const durationHours = (entry.ReceivedMinutes / 60).toFixed(1);
-            const percentage = (entry.FactorOfTotal * 100).toFixed(0);
+        const subBars = entry.SubEntries.map((subEntry) => {
+            const subWidth = Math.round((subEntry.FactorOfTotal / maxFactorOfTotal) * 100);
+            const subDurationHours = (subEntry.ReceivedMinutes / 60).toFixed(1);
+            const subPercentage = (subEntry.FactorOfTotal * 100).toFixed(0);
 
             return (
                 <div
-                    key={entry.TaskName}
+                    key={subEntry.TaskName}
+                    className="bar sub"
+                    style={{
+                        width: subWidth + "%",
+                        backgroundColor: `hsl(${hue}, 40%, 40%)`,
+                    }}
+                >
+                    {subEntry.TaskName}: {subPercentage}% ({subDurationHours} hours)
+                </div>
+            );
+        });
+
+        return (
+            <Fragment key={entry.TaskName}>
+                <div
                     className="bar"
                     style={{
                         width: width + "%",
@@ -22,9 +45,10 @@ export function HorizontalBarView({ timebookSummary }: { timebookSummary: Timebo
                 >
                     {entry.TaskName}: {percentage}% ({durationHours} hours)
                 </div>
-            );
-        },
-    );
+                {subBars}
+            </Fragment>
+        );
+    });
 
     return <div className="bars">{bars}</div>;
 }
@@ -1,4 +1,6 @@
-import { SummaryEntry, TimebookSummary } from "../../../bindings/timebook";
+import { useEffect, useState } from "react";
+
+import { CategorySummaryEntry, TimebookService, TimebookSummary } from "../../../bindings/timebook";
 
 import "./HorizontalBarView.css";
 
@@ -7,27 +9,16 @@ export function HorizontalCategoryBarView({
 }: {
     timebookSummary: TimebookSummary;
 }) {
-    const bars = timebookSummary.Entries.reduce((reduced, entry) => {
-        const existingEntry = reduced.find((e) => e.CategoryShort === entry.CategoryShort);
-        if (existingEntry) {
-            existingEntry.ReceivedMinutes += entry.ReceivedMinutes;
-            existingEntry.FactorOfTotal += entry.FactorOfTotal;
-            existingEntry.ExpectedMinutes += entry.ExpectedMinutes;
-            existingEntry.FactorOfExpected += entry.FactorOfExpected;
-            existingEntry.CountTasks += entry.CountTasks;
-        } else {
-            reduced.push({
-                CategoryShort: entry.CategoryShort,
-                CategoryName: entry.CategoryName,
-                ReceivedMinutes: entry.ReceivedMinutes,
-                FactorOfTotal: entry.FactorOfTotal,
-                ExpectedMinutes: entry.ExpectedMinutes,
-                FactorOfExpected: entry.FactorOfExpected,
-                CountTasks: entry.CountTasks,
-            });
-        }
-        return reduced;
-    }, [] as Omit<SummaryEntry, "TaskShort" | "TaskName">[])
+    const [categoryEntries, setCategoryEntries] = useState<CategorySummaryEntry[]>([]);
+
+    useEffect(() => {
+        TimebookService.GetCategorySummary()
+            .then((entries) => setCategoryEntries(entries ?? []))
+            .catch(() => setCategoryEntries([]));
+    }, [timebookSummary]);
+
+    const bars = categoryEntries
+        .filter((entry) => !entry.NonWorking)
         .sort((a, b) => b.ReceivedMinutes - a.ReceivedMinutes)
         .map((entry, _, entries) => {
             const width = Math.round((entry.FactorOfTotal / entries[0].FactorOfTotal) * 100);
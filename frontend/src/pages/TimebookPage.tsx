@@ -1,19 +1,52 @@
-import { useState, useEffect, PropsWithChildren } from "react";
+import { useState, useEffect, useMemo, PropsWithChildren } from "react";
 
 import { BiSolidBarChartAlt2, BiBarChart, BiSolidPieChartAlt2 } from "react-icons/bi";
 import { GoSync } from "react-icons/go";
 
-import { TimebookService, TimebookSummary } from "../../bindings/timebook";
+import {
+    Comparison,
+    Gap,
+    Language,
+    Settings,
+    SortKey,
+    SummaryEntry,
+    TimebookService,
+    TimebookSummary,
+} from "../../bindings/timebook";
 import { CakeView } from "../components/views/CakeView";
 import { HorizontalBarView } from "../components/views/HorizontalBarView";
 import { HorizontalCategoryBarView } from "../components/views/HorizontalCategoryBarView";
 
 import "./TimebookPage.css";
 
+const sortKeyLabels: [SortKey, string][] = [
+    [SortKey.SortByTaxonomy, "Taxonomy"],
+    [SortKey.SortByReceivedMinutes, "Received"],
+    [SortKey.SortByExpectedMinutes, "Expected"],
+    [SortKey.SortByFactorOfExpected, "Of expected"],
+    [SortKey.SortByFactorOfTotal, "Of total"],
+    [SortKey.SortByName, "Name"],
+];
+
 export function TimebookPage() {
     const [currentView, setCurrentView] = useState<"cake" | "bar" | "barCategory">("barCategory");
     const [timebookSummary, setTimebookSummary] = useState<TimebookSummary | null>(null);
     const [filename, setFilename] = useState<string>("");
+    const [isDirectory, setIsDirectory] = useState<boolean>(false);
+    const [languages, setLanguages] = useState<Language[]>([]);
+    const [language, setLanguage] = useState<Language>(Language.$zero);
+    const [settings, setSettings] = useState<Settings>(new Settings());
+    const [gaps, setGaps] = useState<Gap[]>([]);
+    const [sortKey, setSortKey] = useState<SortKey>(SortKey.SortByTaxonomy);
+    const [sortDescending, setSortDescending] = useState<boolean>(false);
+    const [sortedEntries, setSortedEntries] = useState<SummaryEntry[]>([]);
+    const [comparison, setComparison] = useState<Comparison | null>(null);
+
+    useEffect(() => {
+        TimebookService.GetLanguages().then((languages) => setLanguages(languages ?? []));
+        TimebookService.GetLanguage().then(setLanguage);
+        TimebookService.GetSettings().then(setSettings);
+    }, []);
 
     useEffect(() => {
         if (filename === "") {
@@ -22,11 +55,36 @@ export function TimebookPage() {
         }
 
         handleLoadFile();
-    }, [filename]);
+    }, [filename, isDirectory]);
+
+    useEffect(() => {
+        setComparison(null);
+        if (!timebookSummary) {
+            setGaps([]);
+            return;
+        }
+
+        TimebookService.GetGaps()
+            .then((gaps) => setGaps(gaps ?? []))
+            .catch(() => setGaps([]));
+    }, [timebookSummary]);
+
+    useEffect(() => {
+        if (!timebookSummary) {
+            setSortedEntries([]);
+            return;
+        }
+
+        TimebookService.GetSortedEntries(sortKey, sortDescending)
+            .then((entries) => setSortedEntries(entries ?? []))
+            .catch(() => setSortedEntries(timebookSummary.Entries));
+    }, [timebookSummary, sortKey, sortDescending]);
 
     async function handleLoadFile() {
         try {
-            const timebookSummary = await TimebookService.LoadFile(filename);
+            const timebookSummary = isDirectory
+                ? await TimebookService.LoadDirectory(filename)
+                : await TimebookService.LoadFile(filename);
             if (!timebookSummary) {
                 setTimebookSummary(null);
                 return;
@@ -39,27 +97,99 @@ export function TimebookPage() {
         }
     }
 
+    async function handleLanguageChange(newLanguage: Language) {
+        try {
+            await TimebookService.SetLanguage(newLanguage);
+            setLanguage(newLanguage);
+            setSettings(new Settings({ ...settings, Language: newLanguage }));
+            if (filename) handleLoadFile();
+        } catch (error) {
+            console.log("Language could not be changed.", error);
+        }
+    }
+
+    async function handleWallClockChange(countWallClockOnly: boolean) {
+        try {
+            const newSettings = new Settings({ ...settings, CountWallClockOnly: countWallClockOnly });
+            await TimebookService.SaveSettings(newSettings);
+            setSettings(newSettings);
+            if (filename) handleLoadFile();
+        } catch (error) {
+            console.log("Settings could not be saved.", error);
+        }
+    }
+
+    async function handleFormatFile() {
+        try {
+            const changed = await TimebookService.FormatFile(filename);
+            if (changed) handleLoadFile();
+        } catch (error) {
+            console.log("File could not be formatted.", error);
+        }
+    }
+
     async function handleFileSelect() {
         try {
             const filePath = await TimebookService.SelectFile();
             if (!filePath) throw new Error("Selection cancelled, as no file path was returned.");
 
             setFilename(filePath);
+            setIsDirectory(false);
         } catch (error) {
             console.log("File selection was cancelled due to error.", error);
             setFilename("");
         }
     }
 
+    async function handleDirectorySelect() {
+        try {
+            const dirPath = await TimebookService.SelectDirectory();
+            if (!dirPath) throw new Error("Selection cancelled, as no folder path was returned.");
+
+            setFilename(dirPath);
+            setIsDirectory(true);
+        } catch (error) {
+            console.log("Directory selection was cancelled due to error.", error);
+            setFilename("");
+        }
+    }
+
+    async function handleCompare() {
+        try {
+            const filePath = await TimebookService.SelectFile();
+            if (!filePath) throw new Error("Selection cancelled, as no file path was returned.");
+
+            setComparison(await TimebookService.CompareWithFile(filePath));
+        } catch (error) {
+            console.log("Timebooks could not be compared.", error);
+            setComparison(null);
+        }
+    }
+
+    function fileLabel(filePath: string) {
+        return timebookSummary && timebookSummary.Files.length > 1
+            ? `${filePath.split(/[\\/]/).pop()}, `
+            : "";
+    }
+
+    const sortedSummary = useMemo(
+        () =>
+            timebookSummary && new TimebookSummary({ ...timebookSummary, Entries: sortedEntries }),
+        [timebookSummary, sortedEntries],
+    );
+
     return (
         <>
             <div className="toolbar">
                 <button onClick={handleFileSelect}>Open Timebook File</button>
+                <button onClick={handleDirectorySelect}>Open Timebook Folder</button>
                 {filename && (
                     <button onClick={handleLoadFile}>
                         <GoSync />
                     </button>
                 )}
+                {filename && !isDirectory && <button onClick={handleFormatFile}>Format</button>}
+                {timebookSummary && <button onClick={handleCompare}>Compare with…</button>}
                 <div>
                     <CategoryToggleButton
                         currentCategory={currentView}
@@ -85,24 +215,163 @@ export function TimebookPage() {
                         <BiSolidPieChartAlt2 />
                     </CategoryToggleButton>
                 </div>
+                <select
+                    value={sortKey}
+                    onChange={(event) => setSortKey(event.target.value as SortKey)}
+                >
+                    {sortKeyLabels.map(([key, label]) => (
+                        <option key={key} value={key}>
+                            {label}
+                        </option>
+                    ))}
+                </select>
+                <button onClick={() => setSortDescending(!sortDescending)}>
+                    {sortDescending ? "Descending" : "Ascending"}
+                </button>
+                <select
+                    value={language}
+                    onChange={(event) => handleLanguageChange(event.target.value as Language)}
+                >
+                    {languages.map((language) => (
+                        <option key={language} value={language}>
+                            {language.toUpperCase()}
+                        </option>
+                    ))}
+                </select>
+                <label>
+                    <input
+                        type="checkbox"
+                        checked={settings.CountWallClockOnly}
+                        onChange={(event) => handleWallClockChange(event.target.checked)}
+                    />
+                    Wall-clock total
+                </label>
             </div>
             <div className="container">
-                {timebookSummary
-                    ? (currentView === "cake" && <CakeView timebookSummary={timebookSummary} />) ||
+                {sortedSummary
+                    ? (currentView === "cake" && <CakeView timebookSummary={sortedSummary} />) ||
                       (currentView === "bar" && (
-                          <HorizontalBarView timebookSummary={timebookSummary} />
+                          <HorizontalBarView timebookSummary={sortedSummary} />
                       )) ||
                       (currentView === "barCategory" && (
-                          <HorizontalCategoryBarView timebookSummary={timebookSummary} />
+                          <HorizontalCategoryBarView timebookSummary={sortedSummary} />
                       )) ||
                       "No view selected."
                     : "No data to display."}
             </div>
-            <div className="toolbar">{filename && `Selected file: ${filename}`}</div>
+            {timebookSummary && timebookSummary.Scopes.length > 0 && (
+                <ul className="diagnostics">
+                    {timebookSummary.Scopes.map((scope) => {
+                        const expectedEntries = scope.Entries.filter(
+                            (entry) => entry.ExpectedMinutes > 0,
+                        );
+                        const expectedHours = (
+                            expectedEntries.reduce((sum, entry) => sum + entry.ExpectedMinutes, 0) /
+                            60
+                        ).toFixed(1);
+                        const receivedHours = (
+                            expectedEntries.reduce((sum, entry) => sum + entry.ReceivedMinutes, 0) /
+                            60
+                        ).toFixed(1);
+
+                        return (
+                            <li key={`${scope.FilePath}:${scope.Line}`}>
+                                {`${fileLabel(scope.FilePath)}${scope.Heading || `Line ${scope.Line}`}: `}
+                                {`${receivedHours} of ${expectedHours} expected hours`}
+                            </li>
+                        );
+                    })}
+                </ul>
+            )}
+            {timebookSummary && timebookSummary.Files.length > 1 && (
+                <ul className="diagnostics">
+                    {timebookSummary.Files.map((file) => (
+                        <li key={file.FilePath}>
+                            {`${fileLabel(file.FilePath)}${(file.TotalMins / 60).toFixed(1)} hours`}
+                            {file.CountDiagnostics > 0 && ` (${file.CountDiagnostics} problems)`}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {comparison && (
+                <ul className="diagnostics">
+                    <li>
+                        {`Total: ${formatDeltaHours(comparison.DeltaTotalMins)} compared to before`}
+                    </li>
+                    {comparison.CategoryEntries.map((entry) => (
+                        <li key={entry.CategoryShort}>
+                            {`${entry.CategoryName}: ${formatDeltaHours(entry.DeltaMinutes)}`}
+                            {!entry.NonWorking &&
+                                ` (${formatDeltaPoints(entry.DeltaFactorOfTotal)} of total)`}
+                            {entry.New && " (new)"}
+                            {entry.Disappeared && " (no longer logged)"}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {timebookSummary && timebookSummary.NonWorkingMins > 0 && (
+                <div className="toolbar">
+                    {`Breaks: ${(timebookSummary.NonWorkingMins / 60).toFixed(1)} hours (not included above)`}
+                </div>
+            )}
+            {timebookSummary && timebookSummary.InProgress.length > 0 && (
+                <div className="toolbar">
+                    {timebookSummary.InProgress.map((task) => (
+                        <span key={`${task.Date}-${task.StartTime}`}>
+                            {`In progress: ${task.TaskShort} since ${task.StartTime} (${(task.DurationMins / 60).toFixed(1)} hours)`}
+                        </span>
+                    ))}
+                </div>
+            )}
+            {timebookSummary && timebookSummary.Diagnostics.length > 0 && (
+                <ul className="diagnostics">
+                    {timebookSummary.Diagnostics.map((diagnostic) => (
+                        <li
+                            key={`${diagnostic.FilePath}:${diagnostic.Line}:${diagnostic.Column}`}
+                            className={diagnostic.Severity}
+                        >
+                            {`${fileLabel(diagnostic.FilePath)}Line ${diagnostic.Line}${diagnostic.Column > 0 ? `:${diagnostic.Column}` : ""}`}
+                            {` (${diagnostic.Severity}): ${diagnostic.Message}`}
+                            <code>{diagnostic.Text}</code>
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {gaps.length > 0 && (
+                <ul className="diagnostics">
+                    {gaps.map((gap) => (
+                        <li
+                            key={`${gap.FilePath}:${gap.LineBefore}:${gap.LineAfter}`}
+                            className="gap"
+                        >
+                            {`${fileLabel(gap.FilePath)}Not logged between line ${gap.LineBefore} and ${gap.LineAfter}: `}
+                            {`${gap.StartTime} - ${gap.EndTime} (${gap.UnloggedMins} minutes)`}
+                        </li>
+                    ))}
+                </ul>
+            )}
+            {timebookSummary && timebookSummary.Metadata.Person && (
+                <div className="toolbar">
+                    {`Timebook of ${timebookSummary.Metadata.Person}`}
+                    {timebookSummary.Metadata.WeeklyHours > 0 &&
+                        ` (${timebookSummary.Metadata.WeeklyHours} hours per week)`}
+                </div>
+            )}
+            <div className="toolbar">
+                {filename && `Selected ${isDirectory ? "folder" : "file"}: ${filename}`}
+            </div>
         </>
     );
 }
 
+function formatDeltaHours(deltaMinutes: number) {
+    return `${deltaMinutes >= 0 ? "+" : ""}${(deltaMinutes / 60).toFixed(1)} hours`;
+}
+
+function formatDeltaPoints(deltaFactor: number) {
+    return `${deltaFactor >= 0 ? "+" : ""}${(deltaFactor * 100).toFixed(0)} points`;
+}
+
 function CategoryToggleButton<Category extends string>(
     props: PropsWithChildren<{
         currentCategory: string;
@@ -4,7 +4,10 @@ go 1.24.0
 
 toolchain go1.24.5
 
-require github.com/wailsapp/wails/v3 v3.0.0-alpha.25
+require (
+	github.com/wailsapp/wails/v3 v3.0.0-alpha.25
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	dario.cat/mergo v1.0.1 // indirect
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"sort"
+)
+
+// Compare a previous timebook with the current one, e.g. September with October
+// Both summaries are taken as given, so they may come from single files or
+// from whole directories.
+func (t *TimebookService) Compare(previous TimebookSummary, current TimebookSummary) Comparison {
+	taxonomy := t.getTimebookTaxonomy(mergeMetadata([]TimebookSummary{previous, current}))
+
+	return compareSummaries(taxonomy, previous, current)
+}
+
+// Compare a timebook file with the last loaded timebook
+// The file is taken as the previous one, the last loaded timebook stays loaded.
+func (t *TimebookService) CompareWithFile(previousFilePath string) (Comparison, error) {
+	if t.currentTimebookSummary == nil {
+		return Comparison{}, errors.New("no timebook loaded")
+	}
+
+	previous, err := t.parseFile(previousFilePath)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	return t.Compare(previous, *t.currentTimebookSummary), nil
+}
+
+// Calculate the changes per task short and per category between two summaries
+func compareSummaries(taxonomy Taxonomy, previous TimebookSummary, current TimebookSummary) Comparison {
+	comparison := Comparison{
+		PreviousTotalMins:     previous.TotalMins,
+		CurrentTotalMins:      current.TotalMins,
+		DeltaTotalMins:        current.TotalMins - previous.TotalMins,
+		Entries:               make([]ComparisonEntry, 0),
+		CategoryEntries:       make([]CategoryComparisonEntry, 0),
+		NewTaskShorts:         make([]TaskShort, 0),
+		DisappearedTaskShorts: make([]TaskShort, 0),
+	}
+
+	// entries of the current timebook win, as their names are up to date
+	entryMap := make(map[TaskShort]ComparisonEntry)
+	for _, entry := range previous.Entries {
+		if entry.ReceivedMinutes == 0 {
+			continue
+		}
+		entryMap[entry.TaskShort] = ComparisonEntry{
+			TaskShort:             entry.TaskShort,
+			TaskName:              entry.TaskName,
+			CategoryShort:         entry.CategoryShort,
+			NonWorking:            entry.NonWorking,
+			PreviousMinutes:       entry.ReceivedMinutes,
+			PreviousFactorOfTotal: entry.FactorOfTotal,
+		}
+	}
+	for _, entry := range current.Entries {
+		if entry.ReceivedMinutes == 0 {
+			continue
+		}
+		comparisonEntry := entryMap[entry.TaskShort]
+		comparisonEntry.TaskShort = entry.TaskShort
+		comparisonEntry.TaskName = entry.TaskName
+		comparisonEntry.CategoryShort = entry.CategoryShort
+		comparisonEntry.NonWorking = entry.NonWorking
+		comparisonEntry.CurrentMinutes = entry.ReceivedMinutes
+		comparisonEntry.CurrentFactorOfTotal = entry.FactorOfTotal
+		entryMap[entry.TaskShort] = comparisonEntry
+	}
+
+	for _, entry := range entryMap {
+		entry.DeltaMinutes = entry.CurrentMinutes - entry.PreviousMinutes
+		entry.DeltaFactorOfTotal = entry.CurrentFactorOfTotal - entry.PreviousFactorOfTotal
+		entry.New = entry.PreviousMinutes == 0
+		entry.Disappeared = entry.CurrentMinutes == 0
+		comparison.Entries = append(comparison.Entries, entry)
+	}
+	sort.Slice(comparison.Entries, func(i, j int) bool {
+		return taxonomy.compareTaskShorts(comparison.Entries[i].TaskShort, comparison.Entries[j].TaskShort) < 0
+	})
+
+	for _, entry := range comparison.Entries {
+		if entry.New {
+			comparison.NewTaskShorts = append(comparison.NewTaskShorts, entry.TaskShort)
+		}
+		if entry.Disappeared {
+			comparison.DisappearedTaskShorts = append(comparison.DisappearedTaskShorts, entry.TaskShort)
+		}
+	}
+
+	// categories are rolled up from the entries of each timebook
+	categoryMap := make(map[CategoryShort]CategoryComparisonEntry)
+	for _, categoryEntry := range summarizeCategories(taxonomy, previous.Entries, previous.TotalMins) {
+		if categoryEntry.ReceivedMinutes == 0 {
+			continue
+		}
+		categoryMap[categoryEntry.CategoryShort] = CategoryComparisonEntry{
+			CategoryShort:         categoryEntry.CategoryShort,
+			CategoryName:          categoryEntry.CategoryName,
+			NonWorking:            categoryEntry.NonWorking,
+			PreviousMinutes:       categoryEntry.ReceivedMinutes,
+			PreviousFactorOfTotal: categoryEntry.FactorOfTotal,
+		}
+	}
+	for _, categoryEntry := range summarizeCategories(taxonomy, current.Entries, current.TotalMins) {
+		if categoryEntry.ReceivedMinutes == 0 {
+			continue
+		}
+		comparisonEntry := categoryMap[categoryEntry.CategoryShort]
+		comparisonEntry.CategoryShort = categoryEntry.CategoryShort
+		comparisonEntry.CategoryName = categoryEntry.CategoryName
+		comparisonEntry.NonWorking = categoryEntry.NonWorking
+		comparisonEntry.CurrentMinutes = categoryEntry.ReceivedMinutes
+		comparisonEntry.CurrentFactorOfTotal = categoryEntry.FactorOfTotal
+		categoryMap[categoryEntry.CategoryShort] = comparisonEntry
+	}
+
+	for _, categoryEntry := range categoryMap {
+		categoryEntry.DeltaMinutes = categoryEntry.CurrentMinutes - categoryEntry.PreviousMinutes
+		categoryEntry.DeltaFactorOfTotal = categoryEntry.CurrentFactorOfTotal - categoryEntry.PreviousFactorOfTotal
+		categoryEntry.New = categoryEntry.PreviousMinutes == 0
+		categoryEntry.Disappeared = categoryEntry.CurrentMinutes == 0
+		comparison.CategoryEntries = append(comparison.CategoryEntries, categoryEntry)
+	}
+	sort.Slice(comparison.CategoryEntries, func(i, j int) bool {
+		return taxonomy.compareCategoryShorts(comparison.CategoryEntries[i].CategoryShort, comparison.CategoryEntries[j].CategoryShort) < 0
+	})
+
+	return comparison
+}
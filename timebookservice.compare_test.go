@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCompareSummaries(t *testing.T) {
+	taxonomy := defaultTaxonomy()
+	previous := summarizeTasks(taxonomy, []TaskEntry{
+		rangedTask(1, PlannedWork, 9, 9*60, 13*60),
+		rangedTask(2, Meetings, 9, 13*60, 14*60),
+		rangedTask(3, Support, 9, 14*60, 15*60),
+	}, nil, false)
+	// expected, but not logged support counts as disappeared
+	current := summarizeTasks(taxonomy, []TaskEntry{
+		rangedTask(1, PlannedWork, 16, 9*60, 14*60),
+		rangedTask(2, Meetings, 16, 14*60, 14*60+30),
+		rangedTask(3, Deployments, 16, 14*60+30, 15*60+30),
+	}, map[TaskShort]int{Support: 120}, false)
+
+	comparison := compareSummaries(taxonomy, previous, current)
+
+	if comparison.PreviousTotalMins != 360 || comparison.CurrentTotalMins != 390 || comparison.DeltaTotalMins != 30 {
+		t.Errorf("total minutes = %d, %d, %d; want 360, 390, 30", comparison.PreviousTotalMins, comparison.CurrentTotalMins, comparison.DeltaTotalMins)
+	}
+
+	// entries are ordered by the taxonomy
+	type change struct {
+		short       string
+		previous    int
+		current     int
+		delta       int
+		new         bool
+		disappeared bool
+	}
+	expectedEntries := []change{
+		{short: "A", previous: 240, current: 300, delta: 60},
+		{short: "D", current: 60, delta: 60, new: true},
+		{short: "M", previous: 60, current: 30, delta: -30},
+		{short: "S", previous: 60, delta: -60, disappeared: true},
+	}
+	entries := make([]change, 0)
+	for _, entry := range comparison.Entries {
+		entries = append(entries, change{string(entry.TaskShort), entry.PreviousMinutes, entry.CurrentMinutes, entry.DeltaMinutes, entry.New, entry.Disappeared})
+	}
+	if !reflect.DeepEqual(entries, expectedEntries) {
+		t.Fatalf("Entries = %+v; want %+v", entries, expectedEntries)
+	}
+
+	expectedCategories := []change{
+		{short: "A", previous: 240, current: 300, delta: 60},
+		{short: "M", previous: 60, current: 30, delta: -30},
+		{short: "W", current: 60, delta: 60, new: true},
+		{short: "S", previous: 60, delta: -60, disappeared: true},
+	}
+	categories := make([]change, 0)
+	for _, entry := range comparison.CategoryEntries {
+		categories = append(categories, change{string(entry.CategoryShort), entry.PreviousMinutes, entry.CurrentMinutes, entry.DeltaMinutes, entry.New, entry.Disappeared})
+	}
+	if !reflect.DeepEqual(categories, expectedCategories) {
+		t.Errorf("CategoryEntries = %+v; want %+v", categories, expectedCategories)
+	}
+
+	if !reflect.DeepEqual(comparison.NewTaskShorts, []TaskShort{Deployments}) {
+		t.Errorf("NewTaskShorts = %v; want %v", comparison.NewTaskShorts, []TaskShort{Deployments})
+	}
+	if !reflect.DeepEqual(comparison.DisappearedTaskShorts, []TaskShort{Support}) {
+		t.Errorf("DisappearedTaskShorts = %v; want %v", comparison.DisappearedTaskShorts, []TaskShort{Support})
+	}
+
+	// factors change along with the totals
+	plannedWork := comparison.Entries[0]
+	if expected := 300.0/390 - 240.0/360; math.Abs(plannedWork.DeltaFactorOfTotal-expected) > 1e-9 {
+		t.Errorf("DeltaFactorOfTotal = %v; want %v", plannedWork.DeltaFactorOfTotal, expected)
+	}
+}
@@ -2,21 +2,93 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
 	"timebook/utils"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
+// A task enclosing the following, further indented lines
+type parentTask struct {
+	// 1-based line number of the task
+	line        int
+	indentWidth int
+	// Index of the task within the finished tasks, -1 if it is still running
+	index int
+}
+
 type TimebookService struct {
-	// TODO: this is used to cache the last parsed file, to avoid re-parsing it
-	// for multiple future interpretations (e.g. use as is, sum per categroy).
+	// Cache of the last parsed file, to avoid re-parsing it for multiple
+	// interpretations (e.g. use as is, sum per period, sum per category).
 	currentTimebookSummary *TimebookSummary
+
+	// Task shorts, names and categories used for parsing
+	// If nil, the default taxonomy is used.
+	taxonomy *Taxonomy
+
+	settings Settings
 }
 
 func (t *TimebookService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
+	if filePath, err := configFilePath(settingsFileName); err != nil {
+		log.Printf("Using default settings: %v", err)
+	} else if settings, err := loadSettings(filePath); err != nil {
+		log.Printf("Using default settings: %v", err)
+	} else {
+		t.settings = settings
+	}
+
+	if filePath, err := configFilePath(taxonomyFileName); err != nil {
+		log.Printf("Using default taxonomy: %v", err)
+	} else if taxonomy, err := loadTaxonomy(filePath); err != nil {
+		log.Printf("Using default taxonomy: %v", err)
+	} else {
+		t.taxonomy = &taxonomy
+	}
+
 	return nil
 }
 
+// Get the configured taxonomy or the default one, not yet localized
+func (t *TimebookService) getBaseTaxonomy() Taxonomy {
+	if t.taxonomy != nil {
+		return *t.taxonomy
+	}
+
+	return defaultTaxonomy()
+}
+
+// Get the taxonomy of the last loaded timebook
+func (t *TimebookService) getTaxonomy() Taxonomy {
+	if t.currentTimebookSummary == nil {
+		return t.getTimebookTaxonomy(Metadata{})
+	}
+
+	return t.getTimebookTaxonomy(t.currentTimebookSummary.Metadata)
+}
+
+// Get the taxonomy with the overrides of a timebook merged in, localized to
+// the configured language, or the locale of the timebook if none is configured
+func (t *TimebookService) getTimebookTaxonomy(metadata Metadata) Taxonomy {
+	taxonomy := t.getBaseTaxonomy()
+	if metadata.Taxonomy != nil {
+		taxonomy = taxonomy.merge(*metadata.Taxonomy)
+	}
+
+	language := t.GetLanguage()
+	if t.settings.Language == "" && metadata.Locale != "" {
+		language = metadata.Locale
+	}
+
+	return taxonomy.localize(language)
+}
+
 func (t *TimebookService) LoadFile(filePath string) (TimebookSummary, error) {
 	t.currentTimebookSummary = nil
 	timebookSummary, err := t.parseFile(filePath)
@@ -27,125 +99,347 @@ func (t *TimebookService) LoadFile(filePath string) (TimebookSummary, error) {
 	return timebookSummary, err
 }
 
-// Read a file and parse its content to a map of task short to total duration in minutes
-func (*TimebookService) parseFile(filePath string) (TimebookSummary, error) {
-	lines, err := utils.LoadFileToStringArray(filePath)
+// Read a file and parse its content to a summary of total duration in minutes per task short
+// Lines are read one after the other, only the tasks and sections found are kept.
+func (t *TimebookService) parseFile(filePath string) (TimebookSummary, error) {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return TimebookSummary{}, err
+		return TimebookSummary{}, fmt.Errorf("failed to read file: %w", err)
 	}
+	defer file.Close()
 
-	taskDurationMap := make(map[TaskShort]SummaryEntry)
-	totalMins := 0
+	diagnostics := make([]Diagnostic, 0)
 
-	// parse each line for expected task information
-	for _, line := range lines {
-		parsedExpection, ok := utils.ParseExpectionLine(line)
-		if !ok {
-			continue
-		}
+	// the front matter comes first, its metadata applies to all following lines
+	scanner := utils.NewLineScanner(file)
+	frontMatter := make([]utils.DocumentLine, 0)
+	hasLine := scanner.Scan()
+	for hasLine && scanner.Line().Kind == utils.LineKindFrontMatter {
+		frontMatter = append(frontMatter, scanner.Line())
+		hasLine = scanner.Scan()
+	}
 
-		taskShort := newTaskShortFromInput(parsedExpection.TaskShort)
+	// read the front matter, invalid values are reported and skipped
+	metadata, err := parseMetadata(frontMatter)
+	if err != nil {
+		diagnostics = append(diagnostics, newDiagnostic(0, frontMatter[0].Text, 0, SeverityError, err))
+	}
+	for _, err := range metadata.validate(t.getBaseTaxonomy()) {
+		diagnostics = append(diagnostics, newDiagnostic(0, frontMatter[0].Text, 0, SeverityWarning, err))
+	}
+
+	taxonomy := t.getTimebookTaxonomy(metadata)
+
+	// collect the expectations and the tasks of all entries
+	// Expectations belong to the section they appear in, or to the whole file,
+	// kept per index of the section and -1 for the file. Tasks keep track of
+	// the tasks enclosing the current line by indentation.
+	sectionExpectedMinutes := map[int]map[TaskShort]int{-1: make(map[TaskShort]int)}
+	outline := utils.NewOutline()
+	tasks := make([]TaskEntry, 0)
+	// text of the lines of tasks, to report their overlaps
+	taskLines := make(map[int]string)
+	inProgress := make([]TaskEntry, 0)
+	inProgressMins := 0
+	now := time.Now()
+	parents := make([]parentTask, 0)
+	lineCount := len(frontMatter)
+	for ; hasLine; hasLine = scanner.Scan() {
+		documentLine := scanner.Line()
+		index := documentLine.Number - 1
+		line := documentLine.Text
+		lineCount++
+		outline.Add(documentLine)
+
+		switch documentLine.Kind {
+		case utils.LineKindHeading:
+			// a new day has no enclosing tasks
+			if !documentLine.Date.IsZero() {
+				parents = parents[:0]
+				continue
+			}
+
+		case utils.LineKindExpection:
+			if documentLine.Err != nil {
+				diagnostics = append(diagnostics, newDiagnostic(index, line, 0, SeverityWarning, documentLine.Err))
+			} else {
+				taskShort := taxonomy.taskShortFromInput(documentLine.Expection.TaskShort)
+				sectionIndex := outline.CurrentSection()
+				if sectionExpectedMinutes[sectionIndex] == nil {
+					sectionExpectedMinutes[sectionIndex] = make(map[TaskShort]int)
+				}
+				sectionExpectedMinutes[sectionIndex][taskShort] += documentLine.Expection.DurationMins
+			}
+		}
 
-		// update existing entry
-		if entry, exists := taskDurationMap[taskShort]; exists {
-			entry.ExpectedMinutes += parsedExpection.DurationMins
-			taskDurationMap[taskShort] = entry
+		// blank lines separate the items of loose lists, but do not end them
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		// otherwise create new entry
-		newTask := newSummaryEntry(taskShort)
-		newTask.ExpectedMinutes = parsedExpection.DurationMins
-		taskDurationMap[taskShort] = newTask
-	}
+		// pop parents not enclosing this line
+		indentWidth := documentLine.IndentWidth
+		for len(parents) > 0 && parents[len(parents)-1].indentWidth >= indentWidth {
+			parents = parents[:len(parents)-1]
+		}
 
-	// parse each line for task information
-	filteredLines := utils.FilterAndTrimLines(lines)
-	for _, line := range filteredLines {
-		rawTask, ok := utils.ParseTaskLine(line)
-		if !ok {
+		if documentLine.Kind != utils.LineKindEntry {
+			// list items below a task are notes, other text ends the hierarchy
+			if documentLine.Kind == utils.LineKindNote && len(parents) > 0 {
+				if parent := parents[len(parents)-1]; parent.index != -1 {
+					tasks[parent.index].Notes = append(tasks[parent.index].Notes, documentLine.Note)
+				}
+			} else if indentWidth == 0 {
+				parents = parents[:0]
+			}
 			continue
 		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
 
-		parsedTask, ok := utils.ConvertRawToParsed(rawTask)
-		if !ok {
+		if documentLine.Err != nil {
+			diagnostics = append(diagnostics, newDiagnostic(index, line, indent, SeverityError, documentLine.Err))
 			continue
 		}
+		parsedTask := documentLine.Task
+
+		taskShort := taxonomy.taskShortFromInput(parsedTask.TaskShort)
+		task := newTaskEntry(taskShort, parsedTask)
+		task.FilePath = filePath
+		task.Line = index + 1
+		task.NonWorking = taxonomy.isNonWorking(taskShort)
+
+		if metadata.isOutsidePeriod(task.Date) {
+			err := fmt.Errorf("task on %s lies outside of the period of the timebook", task.Date.Format("2006-01-02"))
+			diagnostics = append(diagnostics, newDiagnostic(index, line, indent, SeverityWarning, err))
+		}
 
-		taskShort := newTaskShortFromInput(parsedTask.TaskShort)
+		// child tasks refine their parent, their time is taken from it
+		if len(parents) > 0 {
+			parent := parents[len(parents)-1]
+			task.ParentLine = parent.line
 
-		// increment total minutes
-		totalMins += parsedTask.DurationMins
+			if parent.index != -1 {
+				enclosing := &tasks[parent.index]
+				if task.Description == "" {
+					task.Description = enclosing.Description
+					task.Tickets = enclosing.Tickets
+					task.Tags = enclosing.Tags
+				}
 
-		// update existing entry
-		if entry, exists := taskDurationMap[taskShort]; exists {
-			entry.ReceivedMinutes += parsedTask.DurationMins
-			entry.CountTasks++
-			taskDurationMap[taskShort] = entry
+				if !task.Running {
+					if task.DurationMins > enclosing.DurationMins {
+						err := fmt.Errorf("logs %d minutes more than the remaining time of line %d", task.DurationMins-enclosing.DurationMins, parent.line)
+						diagnostics = append(diagnostics, newDiagnostic(index, line, indent, SeverityWarning, err))
+					}
+					enclosing.DurationMins = max(0, enclosing.DurationMins-task.DurationMins)
+				}
+			}
+		}
+
+		// running tasks are reported separately, lasting until now
+		if task.Running {
+			// tasks of past days were probably not ended, so they do not count for today
+			startedBeforeToday := isBeforeToday(task.Date, now)
+			if startedBeforeToday {
+				err := fmt.Errorf("task started on %s is still running", task.Date.Format("2006-01-02"))
+				diagnostics = append(diagnostics, newDiagnostic(index, line, indent, SeverityWarning, err))
+			}
+
+			task.DurationMins = runningMinutes(task, now)
+			if !task.NonWorking && !startedBeforeToday {
+				inProgressMins += task.DurationMins
+			}
+			inProgress = append(inProgress, task)
+			parents = append(parents, parentTask{line: task.Line, indentWidth: indentWidth, index: -1})
 			continue
 		}
 
-		// otherwise create new entry
-		newTask := newSummaryEntry(taskShort)
-		newTask.ReceivedMinutes = parsedTask.DurationMins
-		newTask.CountTasks = 1
-		taskDurationMap[taskShort] = newTask
+		tasks = append(tasks, task)
+		taskLines[task.Line] = line
+		parents = append(parents, parentTask{line: task.Line, indentWidth: indentWidth, index: len(tasks) - 1})
+	}
+	if err := scanner.Err(); err != nil {
+		return TimebookSummary{}, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// calculate percentages
-	for taskShort, entry := range taskDurationMap {
-		if entry.ExpectedMinutes > 0 {
-			entry.FactorOfExpected = float64(entry.ReceivedMinutes) / float64(entry.ExpectedMinutes)
+	outline.Close(lineCount)
+	sections := make([]section, len(outline.Sections))
+	for index, outlineSection := range outline.Sections {
+		sections[index] = section{Section: outlineSection, expectedMinutes: make(map[TaskShort]int)}
+		for taskShort, minutes := range sectionExpectedMinutes[index] {
+			sections[index].expectedMinutes[taskShort] = minutes
 		}
+	}
+	expectedMinutes := rollUpExpectations(sections, sectionExpectedMinutes[-1])
+
+	// report tasks logging the same time
+	overlaps := findOverlaps(tasks)
+	for _, overlap := range overlaps {
+		err := fmt.Errorf("overlaps with line %d from %s to %s (%d minutes)", overlap.OtherLine, overlap.StartTime, overlap.EndTime, overlap.DurationMins)
+		diagnostics = append(diagnostics, newDiagnostic(overlap.Line-1, taskLines[overlap.Line], 0, SeverityWarning, err))
+	}
+
+	// diagnostics are collected in separate passes, so restore the order of lines
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+	for index := range diagnostics {
+		diagnostics[index].FilePath = filePath
+	}
+
+	timebookSummary := summarizeTasks(taxonomy, tasks, expectedMinutes, t.settings.CountWallClockOnly)
+	timebookSummary.InProgress = inProgress
+	timebookSummary.InProgressMins = inProgressMins
+	timebookSummary.Diagnostics = diagnostics
+	timebookSummary.Overlaps = overlaps
+	timebookSummary.Metadata = metadata
+	timebookSummary.Scopes = summarizeScopes(taxonomy, sections, tasks, t.settings.CountWallClockOnly)
+	for index := range timebookSummary.Scopes {
+		timebookSummary.Scopes[index].FilePath = filePath
+	}
+	timebookSummary.Files = []FileSummary{newFileSummary(filePath, timebookSummary)}
+	return timebookSummary, nil
+}
+
+func newSummaryEntry(taxonomy Taxonomy, taskShort TaskShort) SummaryEntry {
+	category := taxonomy.categoryOf(taskShort)
+
+	return SummaryEntry{
+		TaskShort:     taskShort,
+		TaskName:      taxonomy.taskName(taskShort),
+		CategoryShort: category,
+		CategoryName:  taxonomy.categoryName(category),
+		NonWorking:    taxonomy.isNonWorking(taskShort),
+		SubEntries:    make([]SummaryEntry, 0),
+	}
+}
 
-		if totalMins > 0 {
-			entry.FactorOfTotal = float64(entry.ReceivedMinutes) / float64(totalMins)
+func newSubSummaryEntry(taxonomy Taxonomy, taskShort TaskShort, subType string) SummaryEntry {
+	subEntry := newSummaryEntry(taxonomy, taskShort)
+	subEntry.SubType = subType
+	subEntry.TaskName = fmt.Sprintf("%s (%s%s)", subEntry.TaskName, taskShort, subType)
+	return subEntry
+}
+
+// Create a diagnostic for an error of the line at the given 0-based index
+// The indent is added to the column of parse errors, as they relate to the trimmed line.
+func newDiagnostic(index int, line string, indent int, severity Severity, err error) Diagnostic {
+	diagnostic := Diagnostic{
+		Line:     index + 1,
+		Severity: severity,
+		Message:  err.Error(),
+		Text:     line,
+	}
+
+	var parseErr *utils.ParseError
+	if errors.As(err, &parseErr) {
+		diagnostic.Message = parseErr.Message
+		if parseErr.Column > 0 {
+			diagnostic.Column = indent + parseErr.Column
 		}
+	}
+
+	return diagnostic
+}
+
+func newTaskEntry(taskShort TaskShort, parsedTask *utils.ParsedTask) TaskEntry {
+	return TaskEntry{
+		TaskShort:    taskShort,
+		SubType:      parsedTask.SubType,
+		Date:         parsedTask.Date,
+		StartTime:    parsedTask.StartTime,
+		EndTime:      parsedTask.EndTime,
+		EndsNextDay:  parsedTask.EndsNextDay,
+		StartMins:    parsedTask.StartMins,
+		EndMins:      parsedTask.EndMins,
+		Running:      parsedTask.Running,
+		DurationOnly: parsedTask.DurationOnly,
+		DurationMins: parsedTask.DurationMins,
+		Description:  parsedTask.Description,
+		Tickets:      parsedTask.Tickets,
+		Tags:         parsedTask.Tags,
+		Notes:        make([]string, 0),
+	}
+}
 
-		taskDurationMap[taskShort] = entry
+// Group the tasks of the last loaded timebook by day, ISO week or month
+// Tasks without a date heading are not part of any period.
+func (t *TimebookService) SummarizeBy(period Period) ([]PeriodSummary, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
 	}
 
-	// convert map to slice
-	entries := make([]SummaryEntry, 0, len(taskDurationMap))
-	for _, entry := range taskDurationMap {
-		entries = append(entries, entry)
+	return summarizeByPeriod(t.getTaxonomy(), *t.currentTimebookSummary, period, t.settings.CountWallClockOnly)
+}
+
+// Get the entries of the last loaded timebook sorted by the given key
+// Entries are in the order of the taxonomy by default, which also breaks ties.
+func (t *TimebookService) GetSortedEntries(key SortKey, descending bool) ([]SummaryEntry, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
 	}
 
-	timebookSummary := TimebookSummary{
-		Entries:   entries,
-		TotalMins: totalMins,
+	return sortEntries(t.getTaxonomy(), t.currentTimebookSummary.Entries, key, descending)
+}
+
+// Sum up the last loaded timebook per category
+func (t *TimebookService) GetCategorySummary() ([]CategorySummaryEntry, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
 	}
-	return timebookSummary, nil
+
+	return summarizeCategories(t.getTaxonomy(), t.currentTimebookSummary.Entries, t.currentTimebookSummary.TotalMins), nil
 }
 
-func newTaskShortFromInput(input string) TaskShort {
-	switch input {
-	case "A":
-		return PlannedWork
-	case "O":
-		return UnplannedWork
-	case "D":
-		return Deployments
-	case "M":
-		return Meetings
-	case "S":
-		return Support
-	case "W":
-		return Maintenance
-	default:
-		return Miscellaneous
+// Sum up the last loaded timebook per referenced ticket
+func (t *TimebookService) GetTicketSummary() ([]LabelSummaryEntry, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
 	}
+
+	return summarizeLabels(t.currentTimebookSummary.Tasks, t.currentTimebookSummary.TotalMins, func(task TaskEntry) []string {
+		return task.Tickets
+	}), nil
 }
 
-func newSummaryEntry(taskShort TaskShort) SummaryEntry {
-	category := taskShort.Category()
+// Sum up the last loaded timebook per tag
+func (t *TimebookService) GetTagSummary() ([]LabelSummaryEntry, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
+	}
 
-	return SummaryEntry{
-		TaskShort:     taskShort,
-		TaskName:      taskShort.FullName(),
-		CategoryShort: category,
-		CategoryName:  category.FullName(),
+	return summarizeLabels(t.currentTimebookSummary.Tasks, t.currentTimebookSummary.TotalMins, func(task TaskEntry) []string {
+		return task.Tags
+	}), nil
+}
+
+// Find the time ranges between tasks of the last loaded timebook, that are not logged
+// The lunch gap of each day is reduced by the configured lunch break allowance.
+// Gaps are searched per file, as tasks of different files do not follow each other.
+func (t *TimebookService) GetGaps() ([]Gap, error) {
+	if t.currentTimebookSummary == nil {
+		return nil, errors.New("no timebook loaded")
 	}
+
+	gaps := make([]Gap, 0)
+	for _, file := range t.currentTimebookSummary.Files {
+		fileTasks := make([]TaskEntry, 0)
+		for _, task := range t.currentTimebookSummary.Tasks {
+			if task.FilePath == file.FilePath {
+				fileTasks = append(fileTasks, task)
+			}
+		}
+
+		gaps = append(gaps, findGaps(fileTasks, t.getMinGapMins(), t.settings.LunchBreakMins)...)
+	}
+
+	return gaps, nil
+}
+
+// Rewrite a timebook file into its canonical form
+// Returns whether the file changed, it has to be loaded again to see the changes.
+func (*TimebookService) FormatFile(filePath string) (bool, error) {
+	return utils.FormatFile(filePath)
 }
 
 func (*TimebookService) SelectFile() (string, error) {
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"timebook/utils"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// Load multiple timebook files and merge them into one summary
+// Each file is parsed on its own, so expectations, sections, overlaps and
+// gaps stay within their file. Entries and totals cover all files.
+func (t *TimebookService) LoadFiles(filePaths []string) (TimebookSummary, error) {
+	t.currentTimebookSummary = nil
+	if len(filePaths) == 0 {
+		return TimebookSummary{}, errors.New("no timebook files given")
+	}
+
+	summaries := make([]TimebookSummary, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		summary, err := t.parseFile(filePath)
+		if err != nil {
+			return TimebookSummary{}, fmt.Errorf("failed to load %s: %w", filePath, err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	timebookSummary := t.mergeSummaries(summaries)
+	t.currentTimebookSummary = &timebookSummary
+	return timebookSummary, nil
+}
+
+// Load all timebook files of a directory, ordered by name
+func (t *TimebookService) LoadDirectory(dirPath string) (TimebookSummary, error) {
+	t.currentTimebookSummary = nil
+	filePaths, err := utils.ListTimebookFiles(dirPath)
+	if err != nil {
+		return TimebookSummary{}, err
+	}
+	if len(filePaths) == 0 {
+		return TimebookSummary{}, fmt.Errorf("no timebook files found in %s", dirPath)
+	}
+
+	return t.LoadFiles(filePaths)
+}
+
+func (*TimebookService) SelectDirectory() (string, error) {
+	dialog := application.OpenFileDialog()
+
+	dialog.CanChooseFiles(false)
+	dialog.CanChooseDirectories(true)
+	dialog.ShowHiddenFiles(true)
+
+	dialog.SetTitle("Select Timebook Directory")
+
+	return dialog.PromptForSingleSelection()
+}
+
+// Merge the summaries of multiple files into one summary of all their tasks
+// Expectations of the files add up, per-file results are kept in order.
+func (t *TimebookService) mergeSummaries(summaries []TimebookSummary) TimebookSummary {
+	metadata := mergeMetadata(summaries)
+	taxonomy := t.getTimebookTaxonomy(metadata)
+
+	tasks := make([]TaskEntry, 0)
+	expectedMinutes := make(map[TaskShort]int)
+	inProgress := make([]TaskEntry, 0)
+	inProgressMins := 0
+	diagnostics := make([]Diagnostic, 0)
+	overlaps := make([]Overlap, 0)
+	scopes := make([]ScopeSummary, 0)
+	files := make([]FileSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		tasks = append(tasks, summary.Tasks...)
+		for _, entry := range summary.Entries {
+			if entry.ExpectedMinutes > 0 {
+				expectedMinutes[entry.TaskShort] += entry.ExpectedMinutes
+			}
+		}
+
+		inProgress = append(inProgress, summary.InProgress...)
+		inProgressMins += summary.InProgressMins
+		diagnostics = append(diagnostics, summary.Diagnostics...)
+		overlaps = append(overlaps, summary.Overlaps...)
+		scopes = append(scopes, summary.Scopes...)
+		files = append(files, summary.Files...)
+	}
+
+	timebookSummary := summarizeTasks(taxonomy, tasks, expectedMinutes, t.settings.CountWallClockOnly)
+	timebookSummary.InProgress = inProgress
+	timebookSummary.InProgressMins = inProgressMins
+	timebookSummary.Diagnostics = diagnostics
+	timebookSummary.Overlaps = overlaps
+	timebookSummary.Metadata = metadata
+	timebookSummary.Scopes = scopes
+	timebookSummary.Files = files
+	return timebookSummary
+}
+
+// Combine the metadata of multiple files
+// Values differing between the files are left empty, the period spans all
+// files if known for each of them and taxonomies are merged in order.
+func mergeMetadata(summaries []TimebookSummary) Metadata {
+	merged := summaries[0].Metadata
+	merged.Taxonomy = nil
+
+	for _, summary := range summaries {
+		metadata := summary.Metadata
+		if metadata.Person != merged.Person {
+			merged.Person = ""
+		}
+		if metadata.WeeklyHours != merged.WeeklyHours {
+			merged.WeeklyHours = 0
+		}
+		if metadata.Locale != merged.Locale {
+			merged.Locale = ""
+		}
+
+		if metadata.PeriodStart.IsZero() || metadata.PeriodEnd.IsZero() {
+			merged.PeriodStart, merged.PeriodEnd = time.Time{}, time.Time{}
+		}
+		if !merged.PeriodStart.IsZero() && metadata.PeriodStart.Before(merged.PeriodStart) {
+			merged.PeriodStart = metadata.PeriodStart
+		}
+		if !merged.PeriodEnd.IsZero() && metadata.PeriodEnd.After(merged.PeriodEnd) {
+			merged.PeriodEnd = metadata.PeriodEnd
+		}
+
+		if metadata.Taxonomy != nil {
+			taxonomy := Taxonomy{}
+			if merged.Taxonomy != nil {
+				taxonomy = *merged.Taxonomy
+			}
+			taxonomy = taxonomy.merge(*metadata.Taxonomy)
+			merged.Taxonomy = &taxonomy
+		}
+	}
+
+	return merged
+}
+
+// Summarize a parsed file for the per-file results of a merged summary
+func newFileSummary(filePath string, summary TimebookSummary) FileSummary {
+	start, end := dateRangeOf(summary.Tasks)
+
+	return FileSummary{
+		FilePath:         filePath,
+		Metadata:         summary.Metadata,
+		Start:            start,
+		End:              end,
+		Entries:          summary.Entries,
+		TotalMins:        summary.TotalMins,
+		NonWorkingMins:   summary.NonWorkingMins,
+		InProgressMins:   summary.InProgressMins,
+		CountDiagnostics: len(summary.Diagnostics),
+	}
+}
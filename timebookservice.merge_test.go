@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeMetadata(t *testing.T) {
+	// build a summary of a file with the given metadata only
+	withMetadata := func(metadata Metadata) TimebookSummary {
+		return TimebookSummary{Metadata: metadata}
+	}
+	day := func(month time.Month, dayOfMonth int) time.Time {
+		return time.Date(2025, month, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		summaries []TimebookSummary
+		expected  Metadata
+	}{
+		{
+			name: "Equal values are kept",
+			summaries: []TimebookSummary{
+				withMetadata(Metadata{Person: "Jane Doe", WeeklyHours: 40, Locale: "de"}),
+				withMetadata(Metadata{Person: "Jane Doe", WeeklyHours: 40, Locale: "de"}),
+			},
+			expected: Metadata{Person: "Jane Doe", WeeklyHours: 40, Locale: "de"},
+		},
+		{
+			name: "Differing values are left empty",
+			summaries: []TimebookSummary{
+				withMetadata(Metadata{Person: "Jane Doe", WeeklyHours: 40, Locale: "de"}),
+				withMetadata(Metadata{Person: "John Doe", WeeklyHours: 40, Locale: "en"}),
+			},
+			expected: Metadata{WeeklyHours: 40},
+		},
+		{
+			name: "Periods span all files",
+			summaries: []TimebookSummary{
+				withMetadata(Metadata{PeriodStart: day(time.October, 1), PeriodEnd: day(time.October, 31)}),
+				withMetadata(Metadata{PeriodStart: day(time.September, 1), PeriodEnd: day(time.September, 30)}),
+				withMetadata(Metadata{PeriodStart: day(time.November, 1), PeriodEnd: day(time.November, 30)}),
+			},
+			expected: Metadata{PeriodStart: day(time.September, 1), PeriodEnd: day(time.November, 30)},
+		},
+		{
+			name: "Period unknown for one of the files",
+			summaries: []TimebookSummary{
+				withMetadata(Metadata{PeriodStart: day(time.October, 1), PeriodEnd: day(time.October, 31)}),
+				withMetadata(Metadata{}),
+			},
+			expected: Metadata{},
+		},
+		{
+			name: "Taxonomies are merged in order",
+			summaries: []TimebookSummary{
+				withMetadata(Metadata{Taxonomy: &Taxonomy{Tasks: []TaskDefinition{{Short: "R", Name: "Reviews"}}}}),
+				withMetadata(Metadata{}),
+				withMetadata(Metadata{Taxonomy: &Taxonomy{Tasks: []TaskDefinition{{Short: "R", Name: "Code Reviews"}, {Short: "T", Name: "Training"}}}}),
+			},
+			expected: Metadata{Taxonomy: &Taxonomy{
+				Tasks:      []TaskDefinition{{Short: "R", Name: "Code Reviews"}, {Short: "T", Name: "Training"}},
+				Categories: []CategoryDefinition{},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeMetadata(tt.summaries)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("mergeMetadata() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeSummaries(t *testing.T) {
+	dirPath := t.TempDir()
+	contents := map[string]string{
+		"2025-09.md": "> - Planned Work A: 10h\n## 2025-09-30\n- (A 9:00 - 12:00)\n- (A 11:00 - 12:00) overlap\n",
+		"2025-10.md": "> - Planned Work A: 20h\n> - Meetings M: 2h\n## 2025-10-01\n- (M 9:00 - 10:00)\n- (A 25:00 - 26:00)\n",
+	}
+	service := &TimebookService{}
+	summaries := make([]TimebookSummary, 0)
+	for _, name := range []string{"2025-09.md", "2025-10.md"} {
+		filePath := filepath.Join(dirPath, name)
+		if err := os.WriteFile(filePath, []byte(contents[name]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		summary, err := service.parseFile(filePath)
+		if err != nil {
+			t.Fatalf("parseFile() error = %v; want nil", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	merged := service.mergeSummaries(summaries)
+
+	if merged.TotalMins != 5*60 {
+		t.Errorf("TotalMins = %d; want %d", merged.TotalMins, 5*60)
+	}
+	if len(merged.Tasks) != 3 {
+		t.Errorf("Tasks = %+v; want the 3 tasks of both files", merged.Tasks)
+	}
+
+	// expectations of the files add up
+	expected := map[TaskShort]int{PlannedWork: 30 * 60, Meetings: 2 * 60}
+	for _, entry := range merged.Entries {
+		if entry.ExpectedMinutes != expected[entry.TaskShort] {
+			t.Errorf("%s ExpectedMinutes = %d; want %d", entry.TaskShort, entry.ExpectedMinutes, expected[entry.TaskShort])
+		}
+	}
+
+	// results of the files are kept with their file
+	if len(merged.Overlaps) != 1 || merged.Overlaps[0].FilePath != filepath.Join(dirPath, "2025-09.md") {
+		t.Errorf("Overlaps = %+v; want the overlap of the first file", merged.Overlaps)
+	}
+	if len(merged.Diagnostics) != 2 || merged.Diagnostics[1].FilePath != filepath.Join(dirPath, "2025-10.md") {
+		t.Errorf("Diagnostics = %+v; want the overlap of the first and the error of the second file", merged.Diagnostics)
+	}
+	filePaths := make([]string, 0)
+	for _, file := range merged.Files {
+		filePaths = append(filePaths, file.FilePath)
+	}
+	if expectedPaths := []string{filepath.Join(dirPath, "2025-09.md"), filepath.Join(dirPath, "2025-10.md")}; !reflect.DeepEqual(filePaths, expectedPaths) {
+		t.Errorf("Files = %v; want %v", filePaths, expectedPaths)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+// Language of task and category names
+type Language string
+
+const (
+	LanguageGerman  Language = "de"
+	LanguageEnglish Language = "en"
+)
+
+// Language used if none is configured
+const defaultLanguage = LanguageGerman
+
+// All languages with built-in names, in order of presentation
+var languages = []Language{LanguageGerman, LanguageEnglish}
+
+// Built-in names of the default task shorts per language
+var taskNameCatalogue = map[Language]map[TaskShort]string{
+	LanguageGerman: {
+		PlannedWork:   "Geplante Arbeiten",
+		UnplannedWork: "Ungeplante Arbeiten",
+		Deployments:   "Deployments",
+		Meetings:      "Meetings",
+		Support:       "Support",
+		Maintenance:   "Wartung",
+		Miscellaneous: "Verschiedenes",
+		Break:         "Pause",
+	},
+	LanguageEnglish: {
+		PlannedWork:   "Planned Work",
+		UnplannedWork: "Unplanned Work",
+		Deployments:   "Deployments",
+		Meetings:      "Meetings",
+		Support:       "Support",
+		Maintenance:   "Maintenance",
+		Miscellaneous: "Miscellaneous",
+		Break:         "Break",
+	},
+}
+
+// Built-in names of the default category shorts per language
+var categoryNameCatalogue = map[Language]map[CategoryShort]string{
+	LanguageGerman: {
+		PlannedWorkCategory:   "Geplante Arbeiten",
+		UnplannedWorkCategory: "Ungeplante Arbeiten",
+		MeetingsCategory:      "Meetings",
+		MaintenanceCategory:   "Wartung",
+		SupportCategory:       "Support",
+		MiscellaneousCategory: "Verschiedenes",
+		BreakCategory:         "Pausen",
+	},
+	LanguageEnglish: {
+		PlannedWorkCategory:   "Planned Work",
+		UnplannedWorkCategory: "Unplanned Work",
+		MeetingsCategory:      "Meetings",
+		MaintenanceCategory:   "Maintenance",
+		SupportCategory:       "Support",
+		MiscellaneousCategory: "Miscellaneous",
+		BreakCategory:         "Breaks",
+	},
+}
+
+func isKnownLanguage(language Language) bool {
+	for _, known := range languages {
+		if known == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Look up a name in the catalogue, falling back to the default language
+func lookupName[Short comparable](catalogue map[Language]map[Short]string, language Language, short Short) (string, bool) {
+	if name, ok := catalogue[language][short]; ok {
+		return name, true
+	}
+
+	name, ok := catalogue[defaultLanguage][short]
+	return name, ok
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"timebook/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Read the metadata from the lines of a front matter block
+// The lines include both delimiters. Without front matter, empty metadata is returned.
+func parseMetadata(frontMatter []utils.DocumentLine) (Metadata, error) {
+	if len(frontMatter) == 0 {
+		return Metadata{}, nil
+	}
+
+	// keep the opening delimiter, so lines of YAML errors match lines of the file
+	texts := []string{"---"}
+	for _, line := range frontMatter[1 : len(frontMatter)-1] {
+		texts = append(texts, line.Text)
+	}
+	content := strings.Join(texts, "\n")
+
+	// unknown keys are left to other tools reading the front matter
+	var metadata Metadata
+	if err := yaml.NewDecoder(bytes.NewBufferString(content)).Decode(&metadata); err != nil && !errors.Is(err, io.EOF) {
+		return Metadata{}, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	// accept region subtags, as in "en-US"
+	if metadata.Locale != "" {
+		language, _, _ := strings.Cut(strings.ToLower(string(metadata.Locale)), "-")
+		language, _, _ = strings.Cut(language, "_")
+		metadata.Locale = Language(language)
+	}
+
+	return metadata, nil
+}
+
+// Check the metadata for values the timebook cannot be read with
+// Returns one error per problem, the affected values are reset.
+func (m *Metadata) validate(taxonomy Taxonomy) []error {
+	errs := make([]error, 0)
+
+	if m.Locale != "" && !isKnownLanguage(m.Locale) {
+		errs = append(errs, fmt.Errorf("unknown locale: %q", m.Locale))
+		m.Locale = ""
+	}
+
+	if m.WeeklyHours < 0 {
+		errs = append(errs, errors.New("weekly hours must not be negative"))
+		m.WeeklyHours = 0
+	}
+
+	if !m.PeriodStart.IsZero() && !m.PeriodEnd.IsZero() && m.PeriodEnd.Before(m.PeriodStart) {
+		errs = append(errs, errors.New("period ends before it starts"))
+		m.PeriodStart, m.PeriodEnd = time.Time{}, time.Time{}
+	}
+
+	if m.Taxonomy != nil {
+		if err := taxonomy.merge(*m.Taxonomy).validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid taxonomy: %w", err))
+			m.Taxonomy = nil
+		}
+	}
+
+	return errs
+}
+
+// Check whether a date lies outside of the period covered by the timebook
+func (m Metadata) isOutsidePeriod(date time.Time) bool {
+	if date.IsZero() {
+		return false
+	}
+
+	return (!m.PeriodStart.IsZero() && date.Before(m.PeriodStart)) ||
+		(!m.PeriodEnd.IsZero() && date.After(m.PeriodEnd))
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"timebook/utils"
+)
+
+// Get the lines of a front matter block, including both delimiters
+func frontMatterOf(texts ...string) []utils.DocumentLine {
+	lines := make([]utils.DocumentLine, 0, len(texts)+2)
+	for index, text := range append(append([]string{"---"}, texts...), "---") {
+		lines = append(lines, utils.DocumentLine{Kind: utils.LineKindFrontMatter, Number: index + 1, Text: text})
+	}
+
+	return lines
+}
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name          string
+		frontMatter   []utils.DocumentLine
+		expected      Metadata
+		expectedError string
+	}{
+		{
+			name:     "No front matter",
+			expected: Metadata{},
+		},
+		{
+			name:        "Empty block",
+			frontMatter: frontMatterOf(),
+			expected:    Metadata{},
+		},
+		{
+			name: "All keys",
+			frontMatter: frontMatterOf(
+				"person: Jane Doe",
+				"periodStart: 2025-10-01",
+				"periodEnd: 2025-10-31",
+				"weeklyHours: 38.5",
+				"locale: en",
+				"taxonomy:",
+				"    tasks:",
+				"        - { short: R, name: Reviews, category: A }",
+			),
+			expected: Metadata{
+				Person:      "Jane Doe",
+				PeriodStart: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC),
+				WeeklyHours: 38.5,
+				Locale:      LanguageEnglish,
+				Taxonomy: &Taxonomy{
+					Tasks: []TaskDefinition{{Short: "R", Name: "Reviews", Category: PlannedWorkCategory}},
+				},
+			},
+		},
+		{
+			name:        "Locale with region subtag",
+			frontMatter: frontMatterOf("locale: en-US"),
+			expected:    Metadata{Locale: LanguageEnglish},
+		},
+		{
+			name:        "Locale with underscore",
+			frontMatter: frontMatterOf("locale: DE_at"),
+			expected:    Metadata{Locale: LanguageGerman},
+		},
+		{
+			name:        "Unknown keys",
+			frontMatter: frontMatterOf("title: October", "person: Jane Doe"),
+			expected:    Metadata{Person: "Jane Doe"},
+		},
+		{
+			name:          "Invalid date",
+			frontMatter:   frontMatterOf("periodStart: first of October"),
+			expectedError: "invalid front matter",
+		},
+		{
+			name:          "Invalid YAML",
+			frontMatter:   frontMatterOf("person: [Jane Doe"),
+			expectedError: "invalid front matter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseMetadata(tt.frontMatter)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("parseMetadata() error = %v; want %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMetadata() error = %v; want nil", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseMetadata() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		metadata       Metadata
+		expected       Metadata
+		expectedErrors int
+	}{
+		{
+			name:     "Valid metadata",
+			metadata: Metadata{PeriodStart: start, PeriodEnd: end, WeeklyHours: 40, Locale: LanguageEnglish},
+			expected: Metadata{PeriodStart: start, PeriodEnd: end, WeeklyHours: 40, Locale: LanguageEnglish},
+		},
+		{
+			name:           "Unknown locale",
+			metadata:       Metadata{Person: "Jane Doe", Locale: "fr"},
+			expected:       Metadata{Person: "Jane Doe"},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Negative weekly hours",
+			metadata:       Metadata{WeeklyHours: -38.5},
+			expected:       Metadata{},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Period ends before it starts",
+			metadata:       Metadata{PeriodStart: end, PeriodEnd: start},
+			expected:       Metadata{},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Invalid taxonomy",
+			metadata:       Metadata{Taxonomy: &Taxonomy{Tasks: []TaskDefinition{{Short: "R", Category: "T"}}}},
+			expected:       Metadata{},
+			expectedErrors: 1,
+		},
+		{
+			name:           "Several problems",
+			metadata:       Metadata{Locale: "fr", WeeklyHours: -1, PeriodStart: start},
+			expected:       Metadata{PeriodStart: start},
+			expectedErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := tt.metadata
+			errs := metadata.validate(defaultTaxonomy())
+			if len(errs) != tt.expectedErrors {
+				t.Errorf("validate() = %v; want %d errors", errs, tt.expectedErrors)
+			}
+			if !reflect.DeepEqual(metadata, tt.expected) {
+				t.Errorf("metadata = %+v; want %+v", metadata, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsOutsidePeriod(t *testing.T) {
+	day := func(month time.Month, dayOfMonth int) time.Time {
+		return time.Date(2025, month, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		date     time.Time
+		expected bool
+	}{
+		{name: "Within the period", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: day(10, 9), expected: false},
+		{name: "First day", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: day(10, 1), expected: false},
+		{name: "Last day", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: day(10, 31), expected: false},
+		{name: "Before the start", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: day(9, 30), expected: true},
+		{name: "After the end", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: day(11, 1), expected: true},
+		{name: "Open start", metadata: Metadata{PeriodEnd: day(10, 31)}, date: day(1, 1), expected: false},
+		{name: "Open end", metadata: Metadata{PeriodStart: day(10, 1)}, date: day(12, 31), expected: false},
+		{name: "Unknown date", metadata: Metadata{PeriodStart: day(10, 1), PeriodEnd: day(10, 31)}, date: time.Time{}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.metadata.isOutsidePeriod(tt.date); result != tt.expected {
+				t.Errorf("isOutsidePeriod(%s) = %v; want %v", tt.date.Format("2006-01-02"), result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFileOutsidePeriod(t *testing.T) {
+	summary := parseContent(t, "---\nperiodStart: 2025-10-01\nperiodEnd: 2025-10-31\n---\n"+
+		"## 2025-10-31\n- (A 9:00 - 10:00)\n"+
+		"## 2025-11-03\n- (A 9:00 - 10:00)\n")
+
+	if len(summary.Diagnostics) != 1 {
+		t.Fatalf("Diagnostics = %+v; want one warning", summary.Diagnostics)
+	}
+	diagnostic := summary.Diagnostics[0]
+	if diagnostic.Line != 8 || diagnostic.Severity != SeverityWarning || !strings.Contains(diagnostic.Message, "2025-11-03") {
+		t.Errorf("Diagnostic = %+v; want a warning for the task on 2025-11-03 in line 8", diagnostic)
+	}
+	if summary.TotalMins != 120 {
+		t.Errorf("TotalMins = %d; want 120, as tasks outside of the period still count", summary.TotalMins)
+	}
+}
+
+func TestGetTimebookTaxonomyLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		locale   Language
+		expected string
+	}{
+		{name: "Default language", expected: "Wartung"},
+		{name: "Locale of the timebook", locale: LanguageEnglish, expected: "Maintenance"},
+		{name: "Configured language", settings: Settings{Language: LanguageEnglish}, expected: "Maintenance"},
+		{name: "Configured language wins over the locale", settings: Settings{Language: LanguageGerman}, locale: LanguageEnglish, expected: "Wartung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TimebookService{settings: tt.settings}
+			taxonomy := service.getTimebookTaxonomy(Metadata{Locale: tt.locale})
+			if result := taxonomy.taskName(Maintenance); result != tt.expected {
+				t.Errorf("taskName(W) = %q; want %q", result, tt.expected)
+			}
+		})
+	}
+}
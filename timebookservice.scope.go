@@ -0,0 +1,108 @@
+package main
+
+import (
+	"sort"
+	"time"
+	"timebook/utils"
+)
+
+// A markdown section of the timebook with the expectations written within it
+type section struct {
+	utils.Section
+	// Minutes expected per task short within the section
+	expectedMinutes map[TaskShort]int
+}
+
+// Roll up expectations from nested sections to their enclosing ones
+// Expectations of a section for a task short replace those of its nested
+// sections, so a monthly target is not added to the weekly ones. Returns the
+// expectations of the whole file, rolled up from the top-level ones.
+func rollUpExpectations(sections []section, fileExpectedMinutes map[TaskShort]int) map[TaskShort]int {
+	nestedMinutes := make([]map[TaskShort]int, len(sections))
+	fileNestedMinutes := make(map[TaskShort]int)
+
+	// nested sections follow their parent, so walk backwards
+	for i := len(sections) - 1; i >= 0; i-- {
+		for taskShort, minutes := range nestedMinutes[i] {
+			if _, exists := sections[i].expectedMinutes[taskShort]; !exists {
+				sections[i].expectedMinutes[taskShort] = minutes
+			}
+		}
+
+		target := fileNestedMinutes
+		if parent := sections[i].Parent; parent != -1 {
+			if nestedMinutes[parent] == nil {
+				nestedMinutes[parent] = make(map[TaskShort]int)
+			}
+			target = nestedMinutes[parent]
+		}
+		for taskShort, minutes := range sections[i].expectedMinutes {
+			target[taskShort] += minutes
+		}
+	}
+
+	expectedMinutes := make(map[TaskShort]int)
+	for taskShort, minutes := range fileNestedMinutes {
+		expectedMinutes[taskShort] = minutes
+	}
+	for taskShort, minutes := range fileExpectedMinutes {
+		expectedMinutes[taskShort] = minutes
+	}
+
+	return expectedMinutes
+}
+
+// Sum up the tasks of each section, that has expectations of its own
+// Tasks of nested sections are part of their enclosing sections as well.
+func summarizeScopes(taxonomy Taxonomy, sections []section, tasks []TaskEntry, countWallClockOnly bool) []ScopeSummary {
+	scopes := make([]ScopeSummary, 0)
+
+	for _, section := range sections {
+		if len(section.expectedMinutes) == 0 {
+			continue
+		}
+
+		// tasks are ordered by their line, so those of a section follow each other
+		first := sort.Search(len(tasks), func(i int) bool { return tasks[i].Line-1 >= section.HeadingIndex })
+		last := sort.Search(len(tasks), func(i int) bool { return tasks[i].Line-1 >= section.EndIndex })
+		scopeTasks := append([]TaskEntry{}, tasks[first:last]...)
+
+		summary := summarizeTasks(taxonomy, scopeTasks, section.expectedMinutes, countWallClockOnly)
+		scope := ScopeSummary{
+			Heading:        section.Heading,
+			Line:           section.HeadingIndex + 1,
+			Entries:        summary.Entries,
+			TotalMins:      summary.TotalMins,
+			NonWorkingMins: summary.NonWorkingMins,
+		}
+
+		if section.Parent != -1 {
+			scope.ParentLine = sections[section.Parent].HeadingIndex + 1
+		}
+
+		// the scope spans the days of its dated tasks
+		scope.Start, scope.End = dateRangeOf(scopeTasks)
+
+		scopes = append(scopes, scope)
+	}
+
+	return scopes
+}
+
+// Get the first and last day of the dated tasks, zero if there are none
+func dateRangeOf(tasks []TaskEntry) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, task := range tasks {
+		if task.Date.IsZero() {
+			continue
+		}
+		if start.IsZero() || task.Date.Before(start) {
+			start = task.Date
+		}
+		if end.IsZero() || task.Date.After(end) {
+			end = task.Date
+		}
+	}
+
+	return start, end
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"timebook/utils"
+)
+
+func TestRollUpExpectations(t *testing.T) {
+	// a month with two weeks, sections are given with their own expectations only
+	newSections := func(month map[TaskShort]int, week41 map[TaskShort]int, week42 map[TaskShort]int) []section {
+		return []section{
+			{Section: utils.Section{Heading: "October", Level: 1, Parent: -1}, expectedMinutes: month},
+			{Section: utils.Section{Heading: "Week 41", Level: 2, Parent: 0}, expectedMinutes: week41},
+			{Section: utils.Section{Heading: "Week 42", Level: 2, Parent: 0}, expectedMinutes: week42},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		sections            []section
+		fileExpectedMinutes map[TaskShort]int
+		expectedMonth       map[TaskShort]int
+		expectedFile        map[TaskShort]int
+	}{
+		{
+			name:                "Weeks add up to the month",
+			sections:            newSections(map[TaskShort]int{}, map[TaskShort]int{PlannedWork: 2400}, map[TaskShort]int{PlannedWork: 1800}),
+			fileExpectedMinutes: map[TaskShort]int{},
+			expectedMonth:       map[TaskShort]int{PlannedWork: 4200},
+			expectedFile:        map[TaskShort]int{PlannedWork: 4200},
+		},
+		{
+			name:                "Month target replaces the weeks",
+			sections:            newSections(map[TaskShort]int{PlannedWork: 9600}, map[TaskShort]int{PlannedWork: 2400}, map[TaskShort]int{PlannedWork: 2400}),
+			fileExpectedMinutes: map[TaskShort]int{},
+			expectedMonth:       map[TaskShort]int{PlannedWork: 9600},
+			expectedFile:        map[TaskShort]int{PlannedWork: 9600},
+		},
+		{
+			name:                "Month target replaces the weeks of its task short only",
+			sections:            newSections(map[TaskShort]int{PlannedWork: 9600}, map[TaskShort]int{PlannedWork: 2400, Meetings: 300}, map[TaskShort]int{Meetings: 600}),
+			fileExpectedMinutes: map[TaskShort]int{},
+			expectedMonth:       map[TaskShort]int{PlannedWork: 9600, Meetings: 900},
+			expectedFile:        map[TaskShort]int{PlannedWork: 9600, Meetings: 900},
+		},
+		{
+			name:                "File target replaces the sections",
+			sections:            newSections(map[TaskShort]int{}, map[TaskShort]int{PlannedWork: 2400}, map[TaskShort]int{Support: 600}),
+			fileExpectedMinutes: map[TaskShort]int{PlannedWork: 6000},
+			expectedMonth:       map[TaskShort]int{PlannedWork: 2400, Support: 600},
+			expectedFile:        map[TaskShort]int{PlannedWork: 6000, Support: 600},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rollUpExpectations(tt.sections, tt.fileExpectedMinutes)
+			if !reflect.DeepEqual(result, tt.expectedFile) {
+				t.Errorf("rollUpExpectations() = %v; want %v", result, tt.expectedFile)
+			}
+			if month := tt.sections[0].expectedMinutes; !reflect.DeepEqual(month, tt.expectedMonth) {
+				t.Errorf("month expectations = %v; want %v", month, tt.expectedMonth)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Name of the application directory within the user config directory
+const configDirName = "timebook-parser"
+
+// Name of the settings file within the application config directory
+const settingsFileName = "settings.json"
+
+// Shortest gap between tasks to report, if not configured otherwise
+const defaultMinGapMins = 15
+
+// Get the current user settings
+func (t *TimebookService) GetSettings() Settings {
+	return t.settings
+}
+
+// Change and persist the user settings
+// Names of the last loaded timebook are relabeled, other changes apply to the next loaded one.
+func (t *TimebookService) SaveSettings(settings Settings) error {
+	if settings.Language != "" && !isKnownLanguage(settings.Language) {
+		return fmt.Errorf("unknown language: %q", settings.Language)
+	}
+	if settings.MinGapMins < 0 || settings.LunchBreakMins < 0 {
+		return errors.New("gap and lunch break minutes must not be negative")
+	}
+
+	filePath, err := configFilePath(settingsFileName)
+	if err != nil {
+		return err
+	}
+	if err := saveSettings(filePath, settings); err != nil {
+		return err
+	}
+	t.settings = settings
+
+	if t.currentTimebookSummary != nil {
+		taxonomy := t.getTaxonomy()
+		relabelEntries(taxonomy, t.currentTimebookSummary.Entries)
+		for _, scope := range t.currentTimebookSummary.Scopes {
+			relabelEntries(taxonomy, scope.Entries)
+		}
+		for _, file := range t.currentTimebookSummary.Files {
+			relabelEntries(taxonomy, file.Entries)
+		}
+	}
+	return nil
+}
+
+// Get all languages available for task and category names
+func (*TimebookService) GetLanguages() []Language {
+	return languages
+}
+
+// Get the language of task and category names
+func (t *TimebookService) GetLanguage() Language {
+	if t.settings.Language == "" {
+		return defaultLanguage
+	}
+
+	return t.settings.Language
+}
+
+// Change and persist the language of task and category names
+// The last loaded timebook is relabeled accordingly.
+func (t *TimebookService) SetLanguage(language Language) error {
+	if !isKnownLanguage(language) {
+		return fmt.Errorf("unknown language: %q", language)
+	}
+
+	settings := t.settings
+	settings.Language = language
+	return t.SaveSettings(settings)
+}
+
+// Get the shortest gap between tasks to report
+func (t *TimebookService) getMinGapMins() int {
+	if t.settings.MinGapMins == 0 {
+		return defaultMinGapMins
+	}
+
+	return t.settings.MinGapMins
+}
+
+// Get the path of a file within the application config directory
+func configFilePath(fileName string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find config directory: %w", err)
+	}
+
+	return filepath.Join(configDir, configDirName, fileName), nil
+}
+
+// Load the settings file
+// A missing file is no error, the default settings are returned instead.
+func loadSettings(filePath string) (Settings, error) {
+	fileContent, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Settings{}, nil
+	}
+	if err != nil {
+		return Settings{}, fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(fileContent, &settings); err != nil {
+		return Settings{}, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Write the settings file, creating the config directory if needed
+func saveSettings(filePath string, settings Settings) error {
+	fileContent, err := json.MarshalIndent(settings, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize settings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(filePath, fileContent, 0o644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sum up tasks and expectations to a summary per task short
+// Factors are calculated relative to the given tasks only, non-working
+// tasks are summarized separately and have no factor of total.
+// If countWallClockOnly is set, overlapping time counts once only in the total.
+func summarizeTasks(taxonomy Taxonomy, tasks []TaskEntry, expectedMinutes map[TaskShort]int, countWallClockOnly bool) TimebookSummary {
+	taskDurationMap := make(map[TaskShort]SummaryEntry)
+	subTypeDurationMap := make(map[TaskShort]map[string]SummaryEntry)
+	totalMins := 0
+	nonWorkingMins := 0
+
+	// add expected minutes
+	for taskShort, minutes := range expectedMinutes {
+		newTask := newSummaryEntry(taxonomy, taskShort)
+		newTask.ExpectedMinutes = minutes
+		taskDurationMap[taskShort] = newTask
+	}
+
+	// add received minutes
+	for _, task := range tasks {
+		// increment total minutes
+		if task.NonWorking {
+			nonWorkingMins += task.DurationMins
+		} else {
+			totalMins += task.DurationMins
+		}
+
+		// sub types are counted separately, in addition to their task short
+		if task.SubType != "" {
+			if _, exists := subTypeDurationMap[task.TaskShort]; !exists {
+				subTypeDurationMap[task.TaskShort] = make(map[string]SummaryEntry)
+			}
+
+			subEntry, exists := subTypeDurationMap[task.TaskShort][task.SubType]
+			if !exists {
+				subEntry = newSubSummaryEntry(taxonomy, task.TaskShort, task.SubType)
+			}
+			subEntry.ReceivedMinutes += task.DurationMins
+			subEntry.CountTasks++
+			subTypeDurationMap[task.TaskShort][task.SubType] = subEntry
+		}
+
+		// update existing entry
+		if entry, exists := taskDurationMap[task.TaskShort]; exists {
+			entry.ReceivedMinutes += task.DurationMins
+			entry.CountTasks++
+			taskDurationMap[task.TaskShort] = entry
+			continue
+		}
+
+		// otherwise create new entry
+		newTask := newSummaryEntry(taxonomy, task.TaskShort)
+		newTask.ReceivedMinutes = task.DurationMins
+		newTask.CountTasks = 1
+		taskDurationMap[task.TaskShort] = newTask
+	}
+
+	if countWallClockOnly {
+		totalMins = wallClockMinutes(tasks)
+	}
+
+	// calculate percentages
+	for taskShort, entry := range taskDurationMap {
+		if entry.ExpectedMinutes > 0 {
+			entry.FactorOfExpected = float64(entry.ReceivedMinutes) / float64(entry.ExpectedMinutes)
+		}
+
+		if totalMins > 0 && !entry.NonWorking {
+			entry.FactorOfTotal = float64(entry.ReceivedMinutes) / float64(totalMins)
+		}
+
+		for _, subEntry := range subTypeDurationMap[taskShort] {
+			if totalMins > 0 && !subEntry.NonWorking {
+				subEntry.FactorOfTotal = float64(subEntry.ReceivedMinutes) / float64(totalMins)
+			}
+			entry.SubEntries = append(entry.SubEntries, subEntry)
+		}
+		sort.Slice(entry.SubEntries, func(i, j int) bool {
+			return entry.SubEntries[i].SubType < entry.SubEntries[j].SubType
+		})
+
+		taskDurationMap[taskShort] = entry
+	}
+
+	// convert map to slice, in the order of the taxonomy
+	entries := make([]SummaryEntry, 0, len(taskDurationMap))
+	for _, entry := range taskDurationMap {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return taxonomy.compareTaskShorts(entries[i].TaskShort, entries[j].TaskShort) < 0
+	})
+
+	return TimebookSummary{
+		Entries:        entries,
+		TotalMins:      totalMins,
+		NonWorkingMins: nonWorkingMins,
+		Tasks:          tasks,
+	}
+}
+
+// Get the minutes a running task has been running until now, at most until the end of its day
+// Tasks without a date are considered to be started today.
+func runningMinutes(task TaskEntry, now time.Time) int {
+	date := task.Date
+	if date.IsZero() {
+		date = now
+	}
+
+	startedAt := time.Date(date.Year(), date.Month(), date.Day(), 0, task.StartMins, 0, 0, now.Location())
+	endedAt := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, now.Location())
+	if now.Before(endedAt) {
+		endedAt = now
+	}
+
+	elapsedMins := int(endedAt.Sub(startedAt).Minutes())
+	if elapsedMins < 0 {
+		return 0
+	}
+
+	return elapsedMins
+}
+
+// Check whether a date is set and lies before the day of now
+func isBeforeToday(date time.Time, now time.Time) bool {
+	if date.IsZero() {
+		return false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return date.Before(today)
+}
+
+// Update the names of summary entries, e.g. after the language changed
+func relabelEntries(taxonomy Taxonomy, entries []SummaryEntry) {
+	for index, entry := range entries {
+		labeled := newSummaryEntry(taxonomy, entry.TaskShort)
+		if entry.SubType != "" {
+			labeled = newSubSummaryEntry(taxonomy, entry.TaskShort, entry.SubType)
+		}
+
+		entries[index].TaskName = labeled.TaskName
+		entries[index].CategoryName = labeled.CategoryName
+		relabelEntries(taxonomy, entry.SubEntries)
+	}
+}
+
+// Roll up summary entries to their categories, in the order of the taxonomy
+// Factors are recalculated from the combined minutes of each category.
+func summarizeCategories(taxonomy Taxonomy, entries []SummaryEntry, totalMins int) []CategorySummaryEntry {
+	categoryMap := make(map[CategoryShort]CategorySummaryEntry)
+
+	for _, entry := range entries {
+		categoryEntry, exists := categoryMap[entry.CategoryShort]
+		if !exists {
+			categoryEntry = CategorySummaryEntry{
+				CategoryShort: entry.CategoryShort,
+				CategoryName:  entry.CategoryName,
+				NonWorking:    entry.NonWorking,
+				TaskShorts:    make([]TaskShort, 0),
+			}
+		}
+
+		categoryEntry.TaskShorts = append(categoryEntry.TaskShorts, entry.TaskShort)
+		categoryEntry.CountTasks += entry.CountTasks
+		categoryEntry.ExpectedMinutes += entry.ExpectedMinutes
+		categoryEntry.ReceivedMinutes += entry.ReceivedMinutes
+		categoryMap[entry.CategoryShort] = categoryEntry
+	}
+
+	// calculate percentages and convert map to slice
+	categoryEntries := make([]CategorySummaryEntry, 0, len(categoryMap))
+	for _, categoryEntry := range categoryMap {
+		if categoryEntry.ExpectedMinutes > 0 {
+			categoryEntry.FactorOfExpected = float64(categoryEntry.ReceivedMinutes) / float64(categoryEntry.ExpectedMinutes)
+		}
+
+		if totalMins > 0 && !categoryEntry.NonWorking {
+			categoryEntry.FactorOfTotal = float64(categoryEntry.ReceivedMinutes) / float64(totalMins)
+		}
+
+		categoryEntries = append(categoryEntries, categoryEntry)
+	}
+	sort.Slice(categoryEntries, func(i, j int) bool {
+		return taxonomy.compareCategoryShorts(categoryEntries[i].CategoryShort, categoryEntries[j].CategoryShort) < 0
+	})
+
+	return categoryEntries
+}
+
+// Get a sorted copy of summary entries
+// Entries with equal values keep their order, sub entries are not sorted.
+func sortEntries(taxonomy Taxonomy, entries []SummaryEntry, key SortKey, descending bool) ([]SummaryEntry, error) {
+	var compare func(a, b SummaryEntry) int
+	switch key {
+	case SortByTaxonomy:
+		compare = func(a, b SummaryEntry) int { return taxonomy.compareTaskShorts(a.TaskShort, b.TaskShort) }
+	case SortByReceivedMinutes:
+		compare = func(a, b SummaryEntry) int { return a.ReceivedMinutes - b.ReceivedMinutes }
+	case SortByExpectedMinutes:
+		compare = func(a, b SummaryEntry) int { return a.ExpectedMinutes - b.ExpectedMinutes }
+	case SortByFactorOfExpected:
+		compare = func(a, b SummaryEntry) int { return compareFloats(a.FactorOfExpected, b.FactorOfExpected) }
+	case SortByFactorOfTotal:
+		compare = func(a, b SummaryEntry) int { return compareFloats(a.FactorOfTotal, b.FactorOfTotal) }
+	case SortByName:
+		compare = func(a, b SummaryEntry) int {
+			return strings.Compare(strings.ToLower(a.TaskName), strings.ToLower(b.TaskName))
+		}
+	default:
+		return nil, fmt.Errorf("unknown sort key: %q", key)
+	}
+
+	sortedEntries := append([]SummaryEntry{}, entries...)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		if descending {
+			return compare(sortedEntries[i], sortedEntries[j]) > 0
+		}
+		return compare(sortedEntries[i], sortedEntries[j]) < 0
+	})
+
+	return sortedEntries, nil
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Sum up tasks per label (e.g. ticket or tag)
+// Labels are ordered by first appearance, tasks without label and
+// non-working tasks are skipped.
+func summarizeLabels(tasks []TaskEntry, totalMins int, labelsOf func(TaskEntry) []string) []LabelSummaryEntry {
+	labelEntries := make([]LabelSummaryEntry, 0)
+	labelIndexes := make(map[string]int)
+
+	for _, task := range tasks {
+		if task.NonWorking {
+			continue
+		}
+
+		for _, label := range labelsOf(task) {
+			index, exists := labelIndexes[label]
+			if !exists {
+				index = len(labelEntries)
+				labelIndexes[label] = index
+				labelEntries = append(labelEntries, LabelSummaryEntry{Label: label})
+			}
+
+			labelEntries[index].CountTasks++
+			labelEntries[index].ReceivedMinutes += task.DurationMins
+		}
+	}
+
+	// calculate percentages
+	if totalMins > 0 {
+		for i := range labelEntries {
+			labelEntries[i].FactorOfTotal = float64(labelEntries[i].ReceivedMinutes) / float64(totalMins)
+		}
+	}
+
+	return labelEntries
+}
+
+// Group dated tasks by period and sum up each group
+// Periods are ordered by their start, undated tasks are skipped.
+func summarizeByPeriod(taxonomy Taxonomy, summary TimebookSummary, period Period, countWallClockOnly bool) ([]PeriodSummary, error) {
+	tasks := summary.Tasks
+
+	// fail early, even if there are no dated tasks
+	if _, _, _, err := periodOfDate(time.Time{}, period); err != nil {
+		return nil, err
+	}
+
+	tasksPerPeriod := make(map[string][]TaskEntry)
+	periodSummaries := make([]PeriodSummary, 0)
+
+	for _, task := range tasks {
+		if task.Date.IsZero() {
+			continue
+		}
+
+		key, start, end, err := periodOfDate(task.Date, period)
+		if err != nil {
+			return nil, err
+		}
+
+		// remember the period on first occurrence
+		if _, exists := tasksPerPeriod[key]; !exists {
+			periodSummaries = append(periodSummaries, PeriodSummary{
+				Period: key,
+				Start:  start,
+				End:    end,
+			})
+		}
+		tasksPerPeriod[key] = append(tasksPerPeriod[key], task)
+	}
+
+	for i, periodSummary := range periodSummaries {
+		expectedMinutes := expectationsWithin(summary, periodSummary.Start, periodSummary.End)
+		taskSummary := summarizeTasks(taxonomy, tasksPerPeriod[periodSummary.Period], expectedMinutes, countWallClockOnly)
+		periodSummary.Entries = taskSummary.Entries
+		periodSummary.TotalMins = taskSummary.TotalMins
+		periodSummary.NonWorkingMins = taskSummary.NonWorkingMins
+		periodSummaries[i] = periodSummary
+	}
+
+	sort.Slice(periodSummaries, func(i, j int) bool {
+		return periodSummaries[i].Start.Before(periodSummaries[j].Start)
+	})
+
+	return periodSummaries, nil
+}
+
+// Collect the expectations of the largest parts of the timebook within the given days
+// A file lying within the days brings its expectations, otherwise its
+// outermost scopes lying within the days do. Parts without dated tasks are skipped.
+func expectationsWithin(summary TimebookSummary, start time.Time, end time.Time) map[TaskShort]int {
+	isWithin := func(partStart time.Time, partEnd time.Time) bool {
+		return !partStart.IsZero() && !partStart.Before(start) && !partEnd.After(end)
+	}
+	addExpectations := func(expectedMinutes map[TaskShort]int, entries []SummaryEntry) {
+		for _, entry := range entries {
+			if entry.ExpectedMinutes > 0 {
+				expectedMinutes[entry.TaskShort] += entry.ExpectedMinutes
+			}
+		}
+	}
+
+	expectedMinutes := make(map[TaskShort]int)
+	for _, file := range summary.Files {
+		if isWithin(file.Start, file.End) {
+			addExpectations(expectedMinutes, file.Entries)
+			continue
+		}
+
+		scopes := make(map[int]ScopeSummary)
+		for _, scope := range summary.Scopes {
+			if scope.FilePath == file.FilePath {
+				scopes[scope.Line] = scope
+			}
+		}
+		for _, scope := range scopes {
+			parent, hasParent := scopes[scope.ParentLine]
+			if isWithin(scope.Start, scope.End) && !(hasParent && isWithin(parent.Start, parent.End)) {
+				addExpectations(expectedMinutes, scope.Entries)
+			}
+		}
+	}
+
+	return expectedMinutes
+}
+
+// Get key, first and last day of the period containing the given date
+func periodOfDate(date time.Time, period Period) (string, time.Time, time.Time, error) {
+	switch period {
+	case PeriodDay:
+		return date.Format("2006-01-02"), date, date, nil
+
+	case PeriodWeek:
+		// ISO weeks start on monday
+		offset := (int(date.Weekday()) + 6) % 7
+		start := date.AddDate(0, 0, -offset)
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week), start, start.AddDate(0, 0, 6), nil
+
+	case PeriodMonth:
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+		return date.Format("2006-01"), start, start.AddDate(0, 1, -1), nil
+
+	default:
+		return "", time.Time{}, time.Time{}, fmt.Errorf("unknown period: %q", period)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeByPeriodExpectations(t *testing.T) {
+	day := func(dayOfMonth int) time.Time {
+		return time.Date(2025, 10, dayOfMonth, 0, 0, 0, 0, time.UTC)
+	}
+	expectation := func(taskShort TaskShort, minutes int) []SummaryEntry {
+		return []SummaryEntry{{TaskShort: taskShort, ExpectedMinutes: minutes}}
+	}
+
+	// a monthly file with expectations for two weeks, the second one nested in a section
+	summary := TimebookSummary{
+		Tasks: []TaskEntry{
+			{FilePath: "2025-10.md", Line: 3, TaskShort: PlannedWork, Date: day(6), DurationMins: 600},
+			{FilePath: "2025-10.md", Line: 7, TaskShort: PlannedWork, Date: day(14), DurationMins: 900},
+		},
+		Files: []FileSummary{
+			{FilePath: "2025-10.md", Start: day(6), End: day(14), Entries: expectation(PlannedWork, 2400)},
+		},
+		Scopes: []ScopeSummary{
+			{FilePath: "2025-10.md", Line: 1, Start: day(6), End: day(6), Entries: expectation(PlannedWork, 1200)},
+			{FilePath: "2025-10.md", Line: 5, Start: day(14), End: day(14), Entries: expectation(PlannedWork, 1200)},
+			{FilePath: "2025-10.md", Line: 6, ParentLine: 5, Start: day(14), End: day(14), Entries: expectation(PlannedWork, 600)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		period   Period
+		expected map[string]int
+	}{
+		{
+			name:     "Month takes the file",
+			period:   PeriodMonth,
+			expected: map[string]int{"2025-10": 2400},
+		},
+		{
+			name:     "Week takes the outermost scopes",
+			period:   PeriodWeek,
+			expected: map[string]int{"2025-W41": 1200, "2025-W42": 1200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periodSummaries, err := summarizeByPeriod(defaultTaxonomy(), summary, tt.period, false)
+			if err != nil {
+				t.Fatalf("summarizeByPeriod() error = %v; want nil", err)
+			}
+
+			for _, periodSummary := range periodSummaries {
+				if len(periodSummary.Entries) != 1 {
+					t.Fatalf("period %s entries = %+v; want one entry", periodSummary.Period, periodSummary.Entries)
+				}
+				if expected := tt.expected[periodSummary.Period]; periodSummary.Entries[0].ExpectedMinutes != expected {
+					t.Errorf("period %s expected minutes = %d; want %d", periodSummary.Period, periodSummary.Entries[0].ExpectedMinutes, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRunningMinutes(t *testing.T) {
+	now := time.Date(2025, 10, 9, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		task     TaskEntry
+		expected int
+	}{
+		{
+			name:     "Started today",
+			task:     TaskEntry{Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), StartMins: 9 * 60},
+			expected: 5*60 + 30,
+		},
+		{
+			name:     "Undated counts as today",
+			task:     TaskEntry{StartMins: 14 * 60},
+			expected: 30,
+		},
+		{
+			name:     "Started in the future",
+			task:     TaskEntry{Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), StartMins: 15 * 60},
+			expected: 0,
+		},
+		{
+			name:     "Started days ago ends with its day",
+			task:     TaskEntry{Date: time.Date(2025, 10, 2, 0, 0, 0, 0, time.UTC), StartMins: 9 * 60},
+			expected: 15 * 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runningMinutes(tt.task, now)
+			if result != tt.expected {
+				t.Errorf("runningMinutes(%+v) = %d; want %d", tt.task, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSummarizeCategories(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []SummaryEntry
+		totalMins int
+		expected  []CategorySummaryEntry
+	}{
+		{
+			name: "Tasks of a category add up",
+			entries: []SummaryEntry{
+				{TaskShort: Deployments, CategoryShort: MaintenanceCategory, CountTasks: 2, ExpectedMinutes: 120, ReceivedMinutes: 60},
+				{TaskShort: Maintenance, CategoryShort: MaintenanceCategory, CountTasks: 1, ExpectedMinutes: 60, ReceivedMinutes: 30},
+			},
+			totalMins: 360,
+			expected: []CategorySummaryEntry{
+				{
+					CategoryShort:    MaintenanceCategory,
+					TaskShorts:       []TaskShort{Deployments, Maintenance},
+					CountTasks:       3,
+					ExpectedMinutes:  180,
+					ReceivedMinutes:  90,
+					FactorOfExpected: 0.5,
+					FactorOfTotal:    0.25,
+				},
+			},
+		},
+		{
+			name: "Categories in the order of the taxonomy",
+			entries: []SummaryEntry{
+				{TaskShort: Meetings, CategoryShort: MeetingsCategory, CountTasks: 1, ReceivedMinutes: 30},
+				{TaskShort: PlannedWork, CategoryShort: PlannedWorkCategory, CountTasks: 1, ReceivedMinutes: 90},
+			},
+			totalMins: 120,
+			expected: []CategorySummaryEntry{
+				{CategoryShort: PlannedWorkCategory, TaskShorts: []TaskShort{PlannedWork}, CountTasks: 1, ReceivedMinutes: 90, FactorOfTotal: 0.75},
+				{CategoryShort: MeetingsCategory, TaskShorts: []TaskShort{Meetings}, CountTasks: 1, ReceivedMinutes: 30, FactorOfTotal: 0.25},
+			},
+		},
+		{
+			name: "Non-working categories have no factor of total",
+			entries: []SummaryEntry{
+				{TaskShort: Break, CategoryShort: BreakCategory, NonWorking: true, CountTasks: 1, ReceivedMinutes: 30},
+			},
+			totalMins: 120,
+			expected: []CategorySummaryEntry{
+				{CategoryShort: BreakCategory, NonWorking: true, TaskShorts: []TaskShort{Break}, CountTasks: 1, ReceivedMinutes: 30},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := summarizeCategories(defaultTaxonomy(), tt.entries, tt.totalMins)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("summarizeCategories() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFileNonWorking(t *testing.T) {
+	summary := parseContent(t, "## 2025-10-09\n"+
+		"- (A 9:00 - 12:00)\n"+
+		"- (P 12:00 - 12:45) Lunch\n"+
+		"- (M 12:45 - 13:45)\n"+
+		"- (Pk 15:00 - 15:15) Coffee\n")
+
+	if summary.TotalMins != 240 {
+		t.Errorf("TotalMins = %d; want 240", summary.TotalMins)
+	}
+	if summary.NonWorkingMins != 60 {
+		t.Errorf("NonWorkingMins = %d; want 60", summary.NonWorkingMins)
+	}
+
+	factors := make(map[TaskShort]float64)
+	for _, entry := range summary.Entries {
+		factors[entry.TaskShort] = entry.FactorOfTotal
+		if entry.TaskShort != Break {
+			continue
+		}
+		if !entry.NonWorking || entry.ReceivedMinutes != 60 {
+			t.Errorf("Entry of P = %+v; want 60 non-working minutes", entry)
+		}
+		if len(entry.SubEntries) != 1 || entry.SubEntries[0].FactorOfTotal != 0 {
+			t.Errorf("SubEntries of P = %+v; want one without factor of total", entry.SubEntries)
+		}
+	}
+	expectedFactors := map[TaskShort]float64{PlannedWork: 0.75, Break: 0, Meetings: 0.25}
+	if !reflect.DeepEqual(factors, expectedFactors) {
+		t.Errorf("FactorOfTotal = %v; want %v", factors, expectedFactors)
+	}
+
+	categories := summarizeCategories(defaultTaxonomy(), summary.Entries, summary.TotalMins)
+	last := categories[len(categories)-1]
+	if last.CategoryShort != BreakCategory || !last.NonWorking || last.ReceivedMinutes != 60 || last.FactorOfTotal != 0 {
+		t.Errorf("Last category = %+v; want 60 non-working minutes of P without factor of total", last)
+	}
+}
+
+func TestSummarizeTasksOrder(t *testing.T) {
+	tasks := []TaskEntry{
+		{TaskShort: "Z", DurationMins: 30},
+		{TaskShort: Break, DurationMins: 30},
+		{TaskShort: Meetings, DurationMins: 30},
+		{TaskShort: "Y", DurationMins: 30},
+		{TaskShort: PlannedWork, DurationMins: 30},
+	}
+
+	summary := summarizeTasks(defaultTaxonomy(), tasks, nil, false)
+
+	// defined task shorts in the order of the taxonomy, unknown ones last by short
+	expected := []TaskShort{PlannedWork, Meetings, Break, "Y", "Z"}
+	result := make([]TaskShort, 0, len(summary.Entries))
+	for _, entry := range summary.Entries {
+		result = append(result, entry.TaskShort)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Entries = %v; want %v", result, expected)
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	// ties between M and A check that the sort is stable
+	entries := []SummaryEntry{
+		{TaskShort: Maintenance, TaskName: "Wartung", ReceivedMinutes: 60, FactorOfTotal: 0.5},
+		{TaskShort: Meetings, TaskName: "meetings", ReceivedMinutes: 30, ExpectedMinutes: 60, FactorOfExpected: 0.5, FactorOfTotal: 0.25},
+		{TaskShort: PlannedWork, TaskName: "Arbeit", ReceivedMinutes: 30, ExpectedMinutes: 20, FactorOfExpected: 1.5, FactorOfTotal: 0.25},
+		{TaskShort: "Z", TaskName: "Z", ExpectedMinutes: 40},
+	}
+
+	tests := []struct {
+		name       string
+		key        SortKey
+		descending bool
+		expected   []TaskShort
+	}{
+		{name: "Taxonomy", key: SortByTaxonomy, expected: []TaskShort{PlannedWork, Meetings, Maintenance, "Z"}},
+		{name: "Taxonomy descending", key: SortByTaxonomy, descending: true, expected: []TaskShort{"Z", Maintenance, Meetings, PlannedWork}},
+		{name: "Received minutes", key: SortByReceivedMinutes, expected: []TaskShort{"Z", Meetings, PlannedWork, Maintenance}},
+		{name: "Received minutes descending", key: SortByReceivedMinutes, descending: true, expected: []TaskShort{Maintenance, Meetings, PlannedWork, "Z"}},
+		{name: "Expected minutes", key: SortByExpectedMinutes, expected: []TaskShort{Maintenance, PlannedWork, "Z", Meetings}},
+		{name: "Expected minutes descending", key: SortByExpectedMinutes, descending: true, expected: []TaskShort{Meetings, "Z", PlannedWork, Maintenance}},
+		{name: "Factor of expected", key: SortByFactorOfExpected, expected: []TaskShort{Maintenance, "Z", Meetings, PlannedWork}},
+		{name: "Factor of expected descending", key: SortByFactorOfExpected, descending: true, expected: []TaskShort{PlannedWork, Meetings, Maintenance, "Z"}},
+		{name: "Factor of total", key: SortByFactorOfTotal, expected: []TaskShort{"Z", Meetings, PlannedWork, Maintenance}},
+		{name: "Factor of total descending", key: SortByFactorOfTotal, descending: true, expected: []TaskShort{Maintenance, Meetings, PlannedWork, "Z"}},
+		{name: "Name ignoring case", key: SortByName, expected: []TaskShort{PlannedWork, Meetings, Maintenance, "Z"}},
+		{name: "Name descending", key: SortByName, descending: true, expected: []TaskShort{"Z", Maintenance, Meetings, PlannedWork}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortedEntries, err := sortEntries(defaultTaxonomy(), entries, tt.key, tt.descending)
+			if err != nil {
+				t.Fatalf("sortEntries() error = %v; want nil", err)
+			}
+
+			result := make([]TaskShort, 0, len(sortedEntries))
+			for _, entry := range sortedEntries {
+				result = append(result, entry.TaskShort)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("sortEntries() = %v; want %v", result, tt.expected)
+			}
+			if entries[0].TaskShort != Maintenance {
+				t.Errorf("sortEntries() changed the order of the given entries")
+			}
+		})
+	}
+
+	if _, err := sortEntries(defaultTaxonomy(), entries, "duration", false); err == nil {
+		t.Errorf("sortEntries() with unknown key error = nil; want error")
+	}
+}
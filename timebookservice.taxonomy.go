@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Name of the taxonomy file within the application config directory
+const taxonomyFileName = "taxonomy.json"
+
+// The built-in taxonomy, used as base for any user configuration
+// Names are left empty, as they are taken from the catalogue on localization.
+func defaultTaxonomy() Taxonomy {
+	return Taxonomy{
+		Tasks: []TaskDefinition{
+			{Short: PlannedWork, Category: PlannedWorkCategory},
+			{Short: UnplannedWork, Category: UnplannedWorkCategory},
+			{Short: Deployments, Category: MaintenanceCategory},
+			{Short: Meetings, Category: MeetingsCategory},
+			{Short: Support, Category: SupportCategory},
+			{Short: Maintenance, Category: MaintenanceCategory},
+			{Short: Miscellaneous, Category: MiscellaneousCategory},
+			{Short: Break, Category: BreakCategory},
+		},
+		Categories: []CategoryDefinition{
+			{Short: PlannedWorkCategory},
+			{Short: UnplannedWorkCategory},
+			{Short: MeetingsCategory},
+			{Short: MaintenanceCategory},
+			{Short: SupportCategory},
+			{Short: MiscellaneousCategory},
+			{Short: BreakCategory, NonWorking: true},
+		},
+		FallbackTaskShort: Miscellaneous,
+	}
+}
+
+// Load the taxonomy file and merge it into the default taxonomy
+// A missing file is no error, the default taxonomy is returned instead.
+func loadTaxonomy(filePath string) (Taxonomy, error) {
+	fileContent, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return defaultTaxonomy(), nil
+	}
+	if err != nil {
+		return Taxonomy{}, fmt.Errorf("failed to read taxonomy: %w", err)
+	}
+
+	var overrides Taxonomy
+	if err := json.Unmarshal(fileContent, &overrides); err != nil {
+		return Taxonomy{}, fmt.Errorf("failed to parse taxonomy: %w", err)
+	}
+
+	taxonomy := defaultTaxonomy().merge(overrides)
+	if err := taxonomy.validate(); err != nil {
+		return Taxonomy{}, fmt.Errorf("invalid taxonomy: %w", err)
+	}
+
+	return taxonomy, nil
+}
+
+// Merge overrides into a copy of the taxonomy
+// Definitions with a known short replace the existing one, others are appended.
+func (t Taxonomy) merge(overrides Taxonomy) Taxonomy {
+	merged := Taxonomy{
+		Tasks:             append([]TaskDefinition{}, t.Tasks...),
+		Categories:        append([]CategoryDefinition{}, t.Categories...),
+		FallbackTaskShort: t.FallbackTaskShort,
+	}
+
+	for _, task := range overrides.Tasks {
+		task.Short = TaskShort(strings.ToUpper(string(task.Short)))
+		task.Category = CategoryShort(strings.ToUpper(string(task.Category)))
+
+		index := merged.taskIndex(task.Short)
+		if index == -1 {
+			merged.Tasks = append(merged.Tasks, task)
+			continue
+		}
+		merged.Tasks[index] = task
+	}
+
+	for _, category := range overrides.Categories {
+		category.Short = CategoryShort(strings.ToUpper(string(category.Short)))
+
+		index := merged.categoryIndex(category.Short)
+		if index == -1 {
+			merged.Categories = append(merged.Categories, category)
+			continue
+		}
+		merged.Categories[index] = category
+	}
+
+	if overrides.FallbackTaskShort != "" {
+		merged.FallbackTaskShort = TaskShort(strings.ToUpper(string(overrides.FallbackTaskShort)))
+	}
+
+	return merged
+}
+
+// Get a copy of the taxonomy with all names resolved for the given language
+// Localized names win over explicit names, which win over the catalogue.
+func (t Taxonomy) localize(language Language) Taxonomy {
+	localized := t.merge(Taxonomy{})
+
+	for index, task := range localized.Tasks {
+		if name, ok := task.Names[language]; ok {
+			task.Name = name
+		} else if task.Name == "" {
+			task.Name, _ = lookupName(taskNameCatalogue, language, task.Short)
+		}
+		localized.Tasks[index] = task
+	}
+
+	for index, category := range localized.Categories {
+		if name, ok := category.Names[language]; ok {
+			category.Name = name
+		} else if category.Name == "" {
+			category.Name, _ = lookupName(categoryNameCatalogue, language, category.Short)
+		}
+		localized.Categories[index] = category
+	}
+
+	return localized
+}
+
+// Check that all shorts are single letters and all references are defined
+func (t Taxonomy) validate() error {
+	for _, category := range t.Categories {
+		if utf8.RuneCountInString(string(category.Short)) != 1 {
+			return fmt.Errorf("category short %q must be a single letter", category.Short)
+		}
+	}
+
+	for _, task := range t.Tasks {
+		if utf8.RuneCountInString(string(task.Short)) != 1 {
+			return fmt.Errorf("task short %q must be a single letter", task.Short)
+		}
+		if t.categoryIndex(task.Category) == -1 {
+			return fmt.Errorf("task short %q refers to unknown category %q", task.Short, task.Category)
+		}
+	}
+
+	if t.taskIndex(t.FallbackTaskShort) == -1 {
+		return fmt.Errorf("fallback task short %q is not defined", t.FallbackTaskShort)
+	}
+
+	return nil
+}
+
+func (t Taxonomy) taskIndex(taskShort TaskShort) int {
+	for index, task := range t.Tasks {
+		if task.Short == taskShort {
+			return index
+		}
+	}
+
+	return -1
+}
+
+func (t Taxonomy) categoryIndex(categoryShort CategoryShort) int {
+	for index, category := range t.Categories {
+		if category.Short == categoryShort {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Compare task shorts by their position in the taxonomy, unknown ones go last
+func (t Taxonomy) compareTaskShorts(a, b TaskShort) int {
+	return compareIndexes(t.taskIndex(a), t.taskIndex(b), string(a), string(b))
+}
+
+// Compare category shorts by their position in the taxonomy, unknown ones go last
+func (t Taxonomy) compareCategoryShorts(a, b CategoryShort) int {
+	return compareIndexes(t.categoryIndex(a), t.categoryIndex(b), string(a), string(b))
+}
+
+// Compare positions within the taxonomy, -1 stands for unknown ones ordered by short
+func compareIndexes(indexA, indexB int, shortA, shortB string) int {
+	switch {
+	case indexA == -1 && indexB == -1:
+		return strings.Compare(shortA, shortB)
+	case indexA == -1:
+		return 1
+	case indexB == -1:
+		return -1
+	default:
+		return indexA - indexB
+	}
+}
+
+// Map a parsed task short to a defined one, unknown shorts map to the fallback
+func (t Taxonomy) taskShortFromInput(input string) TaskShort {
+	taskShort := TaskShort(input)
+	if t.taskIndex(taskShort) == -1 {
+		return t.FallbackTaskShort
+	}
+
+	return taskShort
+}
+
+// Get the name of a task short, or the short itself if it has no name
+func (t Taxonomy) taskName(taskShort TaskShort) string {
+	index := t.taskIndex(taskShort)
+	if index == -1 || t.Tasks[index].Name == "" {
+		return string(taskShort)
+	}
+
+	return t.Tasks[index].Name
+}
+
+func (t Taxonomy) categoryOf(taskShort TaskShort) CategoryShort {
+	index := t.taskIndex(taskShort)
+	if index == -1 {
+		index = t.taskIndex(t.FallbackTaskShort)
+	}
+	if index == -1 {
+		return MiscellaneousCategory
+	}
+
+	return t.Tasks[index].Category
+}
+
+// Check whether a task short belongs to a category of non-working time
+func (t Taxonomy) isNonWorking(taskShort TaskShort) bool {
+	index := t.categoryIndex(t.categoryOf(taskShort))
+	if index == -1 {
+		return false
+	}
+
+	return t.Categories[index].NonWorking
+}
+
+// Get the name of a category short, or the short itself if it has no name
+func (t Taxonomy) categoryName(categoryShort CategoryShort) string {
+	index := t.categoryIndex(categoryShort)
+	if index == -1 || t.Categories[index].Name == "" {
+		return string(categoryShort)
+	}
+
+	return t.Categories[index].Name
+}
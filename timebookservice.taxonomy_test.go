@@ -0,0 +1,221 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadTaxonomy(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       *string
+		expectedError string
+		check         func(t *testing.T, taxonomy Taxonomy)
+	}{
+		{
+			name: "Missing file",
+			check: func(t *testing.T, taxonomy Taxonomy) {
+				if !reflect.DeepEqual(taxonomy, defaultTaxonomy()) {
+					t.Errorf("Expected the default taxonomy, got %+v", taxonomy)
+				}
+			},
+		},
+		{
+			name:          "Invalid JSON",
+			content:       ptr(`{"Tasks": [`),
+			expectedError: "failed to parse taxonomy",
+		},
+		{
+			name:          "Multi-letter short",
+			content:       ptr(`{"Tasks": [{"Short": "RV", "Category": "A"}]}`),
+			expectedError: `task short "RV" must be a single letter`,
+		},
+		{
+			name:          "Unknown category",
+			content:       ptr(`{"Tasks": [{"Short": "R", "Category": "T"}]}`),
+			expectedError: `task short "R" refers to unknown category "T"`,
+		},
+		{
+			name:          "Undefined fallback",
+			content:       ptr(`{"FallbackTaskShort": "R"}`),
+			expectedError: `fallback task short "R" is not defined`,
+		},
+		{
+			name: "Overrides and additions",
+			content: ptr(`{
+				"Tasks": [
+					{"Short": "r", "Name": "Reviews", "Category": "a"},
+					{"Short": "m", "Name": "Calls", "Category": "m"}
+				],
+				"FallbackTaskShort": "r"
+			}`),
+			check: func(t *testing.T, taxonomy Taxonomy) {
+				if len(taxonomy.Tasks) != len(defaultTaxonomy().Tasks)+1 {
+					t.Fatalf("Expected one additional task, got %d tasks", len(taxonomy.Tasks))
+				}
+				expectedAddition := TaskDefinition{Short: "R", Name: "Reviews", Category: PlannedWorkCategory}
+				if addition := taxonomy.Tasks[len(taxonomy.Tasks)-1]; !reflect.DeepEqual(addition, expectedAddition) {
+					t.Errorf("Expected addition %+v, got %+v", expectedAddition, addition)
+				}
+				expectedOverride := TaskDefinition{Short: Meetings, Name: "Calls", Category: MeetingsCategory}
+				if override := taxonomy.Tasks[taxonomy.taskIndex(Meetings)]; !reflect.DeepEqual(override, expectedOverride) {
+					t.Errorf("Expected override %+v, got %+v", expectedOverride, override)
+				}
+				if taxonomy.FallbackTaskShort != "R" {
+					t.Errorf("Expected fallback task short R, got %q", taxonomy.FallbackTaskShort)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), taxonomyFileName)
+			if tt.content != nil {
+				if err := os.WriteFile(filePath, []byte(*tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			taxonomy, err := loadTaxonomy(filePath)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.check(t, taxonomy)
+		})
+	}
+}
+
+func TestMergeTaxonomy(t *testing.T) {
+	base := defaultTaxonomy()
+	merged := base.merge(Taxonomy{
+		Categories: []CategoryDefinition{{Short: "p", Name: "Pausen"}},
+	})
+
+	// the override replaces the definition as a whole, in place
+	index := merged.categoryIndex(BreakCategory)
+	if index != base.categoryIndex(BreakCategory) {
+		t.Fatalf("Expected category P at index %d, got %d", base.categoryIndex(BreakCategory), index)
+	}
+	if expected := (CategoryDefinition{Short: BreakCategory, Name: "Pausen"}); !reflect.DeepEqual(merged.Categories[index], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, merged.Categories[index])
+	}
+
+	// the base taxonomy is left untouched
+	if !reflect.DeepEqual(base, defaultTaxonomy()) {
+		t.Errorf("Expected the base taxonomy to be unchanged, got %+v", base)
+	}
+}
+
+func TestTaskShortFromInput(t *testing.T) {
+	taxonomy := defaultTaxonomy().merge(Taxonomy{
+		Tasks: []TaskDefinition{{Short: "R", Category: PlannedWorkCategory}},
+	})
+
+	tests := []struct {
+		input    string
+		expected TaskShort
+	}{
+		{input: "A", expected: PlannedWork},
+		{input: "R", expected: "R"},
+		{input: "X", expected: Miscellaneous},
+		{input: "", expected: Miscellaneous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if actual := taxonomy.taskShortFromInput(tt.input); actual != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestLocalizeTaxonomy(t *testing.T) {
+	taxonomy := defaultTaxonomy().merge(Taxonomy{
+		Tasks: []TaskDefinition{
+			{Short: PlannedWork, Name: "Projekte", Names: map[Language]string{LanguageEnglish: "Projects"}, Category: PlannedWorkCategory},
+			{Short: "R", Name: "Reviews", Category: PlannedWorkCategory},
+			{Short: "T", Category: PlannedWorkCategory},
+		},
+	})
+
+	tests := []struct {
+		name      string
+		language  Language
+		taskShort TaskShort
+		expected  string
+	}{
+		{name: "Localized name", language: LanguageEnglish, taskShort: PlannedWork, expected: "Projects"},
+		{name: "Explicit name without localized one", language: LanguageGerman, taskShort: PlannedWork, expected: "Projekte"},
+		{name: "Explicit name", language: LanguageEnglish, taskShort: "R", expected: "Reviews"},
+		{name: "Catalogue", language: LanguageEnglish, taskShort: Maintenance, expected: "Maintenance"},
+		{name: "Catalogue of the default language", language: "fr", taskShort: Maintenance, expected: "Wartung"},
+		{name: "No name at all", language: LanguageEnglish, taskShort: "T", expected: "T"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := taxonomy.localize(tt.language).taskName(tt.taskShort); actual != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+
+	// the category names follow the same rules
+	if actual := taxonomy.localize(LanguageEnglish).categoryName(BreakCategory); actual != "Breaks" {
+		t.Errorf("Expected category name %q, got %q", "Breaks", actual)
+	}
+}
+
+func TestSaveSettingsRelabels(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv("HOME", configDir)
+
+	taxonomy := defaultTaxonomy().localize(LanguageGerman)
+	entries := func() []SummaryEntry {
+		entry := newSummaryEntry(taxonomy, Maintenance)
+		entry.SubEntries = []SummaryEntry{newSubSummaryEntry(taxonomy, Maintenance, "1")}
+		return []SummaryEntry{entry}
+	}
+	service := &TimebookService{
+		currentTimebookSummary: &TimebookSummary{
+			Entries: entries(),
+			Scopes:  []ScopeSummary{{Entries: entries()}},
+			Files:   []FileSummary{{Entries: entries()}},
+		},
+	}
+
+	if err := service.SetLanguage(LanguageEnglish); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	summary := service.currentTimebookSummary
+	for name, entries := range map[string][]SummaryEntry{
+		"Entries": summary.Entries,
+		"Scopes":  summary.Scopes[0].Entries,
+		"Files":   summary.Files[0].Entries,
+	} {
+		if entries[0].TaskName != "Maintenance" || entries[0].CategoryName != "Maintenance" {
+			t.Errorf("%s: expected English names, got %q and %q", name, entries[0].TaskName, entries[0].CategoryName)
+		}
+		if subEntry := entries[0].SubEntries[0]; subEntry.TaskName != "Maintenance (W1)" {
+			t.Errorf("%s: expected sub entry name %q, got %q", name, "Maintenance (W1)", subEntry.TaskName)
+		}
+	}
+}
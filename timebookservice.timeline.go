@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Position of a task, unique across the files of a merged timebook
+type taskRef struct {
+	filePath string
+	line     int
+}
+
+// A task placed on a continuous timeline of minutes
+type timelineSlot struct {
+	task     TaskEntry
+	startMin int
+	endMin   int
+}
+
+// Place all tasks with a time range on a continuous timeline, ordered by start
+// Running and duration-only tasks have no fixed range and are skipped.
+func newTimeline(tasks []TaskEntry) []timelineSlot {
+	timeline := make([]timelineSlot, 0, len(tasks))
+
+	for _, task := range tasks {
+		if task.Running || task.DurationOnly {
+			continue
+		}
+
+		// days since epoch keep tasks of different days apart
+		dayStartMin := int(task.Date.Unix()/(24*60*60)) * 24 * 60
+
+		endMins := task.EndMins
+		if task.EndsNextDay {
+			endMins += 24 * 60
+		}
+
+		timeline = append(timeline, timelineSlot{
+			task:     task,
+			startMin: dayStartMin + task.StartMins,
+			endMin:   dayStartMin + endMins,
+		})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].startMin < timeline[j].startMin
+	})
+
+	return timeline
+}
+
+// Find all pairs of tasks logging the same time
+// Child tasks are part of their parents, so they do not overlap with them.
+func findOverlaps(tasks []TaskEntry) []Overlap {
+	overlaps := make([]Overlap, 0)
+	timeline := newTimeline(tasks)
+	parents := parentsOf(tasks)
+
+	for i, slot := range timeline {
+		// following slots overlap as long as they start before this one ends
+		for _, other := range timeline[i+1:] {
+			if other.startMin >= slot.endMin {
+				break
+			}
+			if isAncestor(parents, slot.task.ref(), other.task.ref()) || isAncestor(parents, other.task.ref(), slot.task.ref()) {
+				continue
+			}
+
+			endMin := min(slot.endMin, other.endMin)
+			overlaps = append(overlaps, Overlap{
+				FilePath:     other.task.FilePath,
+				Date:         other.task.Date,
+				Line:         other.task.Line,
+				OtherLine:    slot.task.Line,
+				StartTime:    formatTimelineMin(other.startMin),
+				EndTime:      formatTimelineMin(endMin),
+				DurationMins: endMin - other.startMin,
+			})
+		}
+	}
+
+	return overlaps
+}
+
+// Time of day, in which the lunch break is looked for
+const (
+	lunchWindowStartMins = 11 * 60
+	lunchWindowEndMins   = 14 * 60
+)
+
+// Find all gaps between consecutive tasks of the same day
+// The gap covering most of the lunch window is reduced by the lunch break
+// allowance. Gaps with less unlogged minutes than minGapMins are not reported.
+func findGaps(tasks []TaskEntry, minGapMins int, lunchBreakMins int) []Gap {
+	gaps := make([]Gap, 0)
+	dayGaps := make([]Gap, 0)
+	dayLunchMins := make([]int, 0)
+
+	// reduce the lunch gap of the day and keep those long enough
+	flushDay := func() {
+		lunchIndex := -1
+		for index, lunchMins := range dayLunchMins {
+			if lunchMins > 0 && (lunchIndex == -1 || lunchMins > dayLunchMins[lunchIndex]) {
+				lunchIndex = index
+			}
+		}
+		if lunchIndex != -1 {
+			dayGaps[lunchIndex].UnloggedMins = max(0, dayGaps[lunchIndex].DurationMins-lunchBreakMins)
+		}
+
+		for _, gap := range dayGaps {
+			if gap.UnloggedMins > 0 && gap.UnloggedMins >= minGapMins {
+				gaps = append(gaps, gap)
+			}
+		}
+		dayGaps = dayGaps[:0]
+		dayLunchMins = dayLunchMins[:0]
+	}
+
+	var previous timelineSlot
+	for i, slot := range newTimeline(tasks) {
+		if i > 0 && !slot.task.Date.Equal(previous.task.Date) {
+			flushDay()
+		} else if i > 0 && slot.startMin > previous.endMin {
+			dayGaps = append(dayGaps, Gap{
+				FilePath:     slot.task.FilePath,
+				Date:         slot.task.Date,
+				LineBefore:   previous.task.Line,
+				LineAfter:    slot.task.Line,
+				StartTime:    formatTimelineMin(previous.endMin),
+				EndTime:      formatTimelineMin(slot.startMin),
+				DurationMins: slot.startMin - previous.endMin,
+				UnloggedMins: slot.startMin - previous.endMin,
+			})
+
+			// minutes of the gap within the lunch window of its day
+			dayStartMin := slot.startMin - slot.task.StartMins
+			lunchStartMin := max(previous.endMin, dayStartMin+lunchWindowStartMins)
+			lunchEndMin := min(slot.startMin, dayStartMin+lunchWindowEndMins)
+			dayLunchMins = append(dayLunchMins, max(0, lunchEndMin-lunchStartMin))
+		}
+
+		// with overlapping tasks, the one ending last closes the range
+		if i == 0 || !slot.task.Date.Equal(previous.task.Date) || slot.endMin > previous.endMin {
+			previous = slot
+		}
+	}
+	flushDay()
+
+	return gaps
+}
+
+// Sum up the logged minutes, counting time logged by multiple tasks once only
+// Duration-only tasks are added as is, running and non-working tasks are skipped.
+// Children lie within the range of a ranged ancestor, so non-working ones are
+// taken from it and duration-only ones are not added again.
+func wallClockMinutes(tasks []TaskEntry) int {
+	workingTasks := make([]TaskEntry, 0, len(tasks))
+	rangedTasks := make(map[taskRef]bool)
+	for _, task := range tasks {
+		if task.NonWorking {
+			continue
+		}
+
+		workingTasks = append(workingTasks, task)
+		if !task.Running && !task.DurationOnly {
+			rangedTasks[task.ref()] = true
+		}
+	}
+	parents := parentsOf(tasks)
+
+	totalMins := 0
+	for _, task := range tasks {
+		withinRange := false
+		for parent, ok := parents[task.ref()]; ok && !withinRange; parent, ok = parents[parent] {
+			withinRange = rangedTasks[parent]
+		}
+
+		if task.NonWorking && withinRange {
+			totalMins -= task.DurationMins
+		} else if !task.NonWorking && task.DurationOnly && !withinRange {
+			totalMins += task.DurationMins
+		}
+	}
+
+	// merge overlapping slots into continuous ranges
+	rangeStart, rangeEnd := 0, 0
+	for i, slot := range newTimeline(workingTasks) {
+		if i > 0 && slot.startMin <= rangeEnd {
+			rangeEnd = max(rangeEnd, slot.endMin)
+			continue
+		}
+
+		totalMins += rangeEnd - rangeStart
+		rangeStart, rangeEnd = slot.startMin, slot.endMin
+	}
+	totalMins += rangeEnd - rangeStart
+
+	return totalMins
+}
+
+// Map each child task to its parent
+func parentsOf(tasks []TaskEntry) map[taskRef]taskRef {
+	parents := make(map[taskRef]taskRef)
+	for _, task := range tasks {
+		if task.ParentLine != 0 {
+			parents[task.ref()] = taskRef{filePath: task.FilePath, line: task.ParentLine}
+		}
+	}
+
+	return parents
+}
+
+// Check whether one task encloses another one
+func isAncestor(parents map[taskRef]taskRef, ancestor taskRef, task taskRef) bool {
+	for parent, ok := parents[task]; ok; parent, ok = parents[parent] {
+		if parent == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get the position of the task
+func (t TaskEntry) ref() taskRef {
+	return taskRef{filePath: t.FilePath, line: t.Line}
+}
+
+// Format a minute of the timeline as time of its day
+func formatTimelineMin(timelineMin int) string {
+	// undated tasks lie before epoch, so keep the remainder positive
+	dayMin := (timelineMin%(24*60) + 24*60) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", dayMin/60, dayMin%60)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Create a finished task of the given range on a day in October 2025
+func rangedTask(line int, taskShort TaskShort, dayOfMonth int, startMins int, endMins int) TaskEntry {
+	durationMins := endMins - startMins
+	endsNextDay := durationMins < 0
+	if endsNextDay {
+		durationMins += 24 * 60
+	}
+
+	return TaskEntry{
+		Line:         line,
+		TaskShort:    taskShort,
+		Date:         time.Date(2025, 10, dayOfMonth, 0, 0, 0, 0, time.UTC),
+		StartTime:    formatTimelineMin(startMins),
+		EndTime:      formatTimelineMin(endMins),
+		StartMins:    startMins,
+		EndMins:      endMins,
+		EndsNextDay:  endsNextDay,
+		DurationMins: durationMins,
+	}
+}
+
+// Nest a task below the task at the given line
+func childOf(parentLine int, task TaskEntry) TaskEntry {
+	task.ParentLine = parentLine
+	return task
+}
+
+// Place a task in the given file
+func inFile(filePath string, task TaskEntry) TaskEntry {
+	task.FilePath = filePath
+	return task
+}
+
+func TestFindOverlaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []TaskEntry
+		expected []Overlap
+	}{
+		{
+			name: "Later task starts within earlier one",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 11*60),
+				rangedTask(3, Meetings, 9, 10*60+30, 11*60),
+			},
+			expected: []Overlap{
+				{Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), Line: 3, OtherLine: 2, StartTime: "10:30", EndTime: "11:00", DurationMins: 30},
+			},
+		},
+		{
+			name: "Adjacent tasks",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				rangedTask(3, Meetings, 9, 10*60, 11*60),
+			},
+			expected: []Overlap{},
+		},
+		{
+			name: "Same range on different days",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				rangedTask(4, PlannedWork, 10, 9*60, 10*60),
+			},
+			expected: []Overlap{},
+		},
+		{
+			name: "Children lie within their ancestors",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 12*60),
+				childOf(2, rangedTask(3, Meetings, 9, 10*60, 11*60)),
+				childOf(3, rangedTask(4, Meetings, 9, 10*60, 10*60+15)),
+			},
+			expected: []Overlap{},
+		},
+		{
+			name: "Overnight task reaches into the next day",
+			tasks: []TaskEntry{
+				rangedTask(2, Support, 9, 23*60+30, 30),
+				rangedTask(4, PlannedWork, 10, 0, 60),
+			},
+			expected: []Overlap{
+				{Date: time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC), Line: 4, OtherLine: 2, StartTime: "00:00", EndTime: "00:30", DurationMins: 30},
+			},
+		},
+		{
+			name: "Parent lines refer to the file of the child",
+			tasks: []TaskEntry{
+				inFile("a.md", rangedTask(2, PlannedWork, 9, 9*60, 12*60)),
+				inFile("b.md", rangedTask(2, Support, 9, 8*60, 9*60)),
+				inFile("b.md", childOf(2, rangedTask(3, Meetings, 9, 10*60, 11*60))),
+			},
+			expected: []Overlap{
+				{FilePath: "b.md", Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), Line: 3, OtherLine: 2, StartTime: "10:00", EndTime: "11:00", DurationMins: 60},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := findOverlaps(tt.tasks)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("findOverlaps() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWallClockMinutes(t *testing.T) {
+	durationOnly := func(line int, taskShort TaskShort, durationMins int) TaskEntry {
+		return TaskEntry{Line: line, TaskShort: taskShort, DurationOnly: true, DurationMins: durationMins}
+	}
+	nonWorking := func(task TaskEntry) TaskEntry {
+		task.NonWorking = true
+		return task
+	}
+
+	tests := []struct {
+		name     string
+		tasks    []TaskEntry
+		expected int
+	}{
+		{
+			name:     "No tasks",
+			tasks:    []TaskEntry{},
+			expected: 0,
+		},
+		{
+			name: "Overlapping time counts once",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 11*60),
+				rangedTask(3, Meetings, 9, 10*60+30, 11*60),
+			},
+			expected: 120,
+		},
+		{
+			name: "Breaks between tasks are not counted",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 12*60),
+				nonWorking(rangedTask(3, Break, 9, 12*60, 13*60)),
+				rangedTask(4, PlannedWork, 9, 13*60, 14*60),
+			},
+			expected: 240,
+		},
+		{
+			name: "Breaks within a parent are taken from it",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 12*60),
+				nonWorking(childOf(2, rangedTask(3, Break, 9, 10*60, 10*60+30))),
+			},
+			expected: 150,
+		},
+		{
+			name: "Duration-only children lie within their parent",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				childOf(2, durationOnly(3, Meetings, 30)),
+			},
+			expected: 60,
+		},
+		{
+			name: "Duration-only tasks are added",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				durationOnly(3, Meetings, 45),
+			},
+			expected: 105,
+		},
+		{
+			name: "Same lines in different files",
+			tasks: []TaskEntry{
+				inFile("a.md", rangedTask(2, PlannedWork, 9, 9*60, 12*60)),
+				inFile("b.md", rangedTask(2, Support, 10, 9*60, 10*60)),
+				inFile("b.md", nonWorking(childOf(2, rangedTask(3, Break, 10, 9*60, 9*60+30)))),
+			},
+			expected: 180 + 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wallClockMinutes(tt.tasks)
+			if result != tt.expected {
+				t.Errorf("wallClockMinutes() = %d; want %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindGaps(t *testing.T) {
+	day := time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		tasks          []TaskEntry
+		minGapMins     int
+		lunchBreakMins int
+		expected       []Gap
+	}{
+		{
+			name: "Gap between two tasks",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				rangedTask(3, PlannedWork, 9, 10*60+30, 11*60),
+			},
+			minGapMins: 15,
+			expected: []Gap{
+				{Date: day, LineBefore: 2, LineAfter: 3, StartTime: "10:00", EndTime: "10:30", DurationMins: 30, UnloggedMins: 30},
+			},
+		},
+		{
+			name: "Short gaps are not reported",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				rangedTask(3, PlannedWork, 9, 10*60+10, 11*60),
+			},
+			minGapMins: 15,
+			expected:   []Gap{},
+		},
+		{
+			name: "Overlapping tasks close the range together",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 12*60),
+				rangedTask(3, Meetings, 9, 10*60, 11*60),
+				rangedTask(4, PlannedWork, 9, 12*60, 13*60),
+			},
+			minGapMins: 15,
+			expected:   []Gap{},
+		},
+		{
+			name: "Lunch break is taken from the midday gap",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 12*60),
+				rangedTask(3, PlannedWork, 9, 12*60+45, 14*60),
+			},
+			minGapMins:     15,
+			lunchBreakMins: 30,
+			expected: []Gap{
+				{Date: day, LineBefore: 2, LineAfter: 3, StartTime: "12:00", EndTime: "12:45", DurationMins: 45, UnloggedMins: 15},
+			},
+		},
+		{
+			name: "Lunch break is not taken from a longer evening gap",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 11*60),
+				rangedTask(3, PlannedWork, 9, 12*60, 14*60),
+				rangedTask(4, Support, 9, 23*60+30, 15),
+			},
+			minGapMins:     15,
+			lunchBreakMins: 60,
+			expected: []Gap{
+				{Date: day, LineBefore: 3, LineAfter: 4, StartTime: "14:00", EndTime: "23:30", DurationMins: 570, UnloggedMins: 570},
+			},
+		},
+		{
+			name: "Days are checked on their own",
+			tasks: []TaskEntry{
+				rangedTask(2, PlannedWork, 9, 9*60, 10*60),
+				rangedTask(4, PlannedWork, 10, 11*60, 12*60),
+			},
+			minGapMins: 15,
+			expected:   []Gap{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := findGaps(tt.tasks, tt.minGapMins, tt.lunchBreakMins)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("findGaps() = %+v; want %+v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -1,21 +1,207 @@
 package main
 
+import "time"
+
 // Summary of timebook entries including total minutes
 type TimebookSummary struct {
-	Entries   []SummaryEntry
+	Entries []SummaryEntry
+	// Minutes worked, without breaks and other non-working time
 	TotalMins int
+	// Minutes of breaks and other non-working time
+	NonWorkingMins int
+	// All finished tasks of the timebook in order of appearance
+	Tasks []TaskEntry
+	// Tasks that are still running, with their duration until now
+	// NOTE: These are not part of Entries, TotalMins or Tasks.
+	InProgress []TaskEntry
+	// Minutes of running tasks started today, without running breaks
+	// NOTE: Tasks of past days are still listed, as they were probably not ended.
+	InProgressMins int
+	// Problems found while parsing, in order of appearance
+	Diagnostics []Diagnostic
+	// Time ranges logged by more than one task
+	Overlaps []Overlap
+	// Information about the timebook, taken from its front matter
+	Metadata Metadata
+	// Summaries of sections with expectations of their own, in order of appearance
+	// NOTE: Entries and TotalMins above include all expectations and tasks.
+	Scopes []ScopeSummary
+	// Summaries of the files the timebook was loaded from, in order of loading
+	Files []FileSummary
+}
+
+// Summary of a single file of a timebook, that may span multiple files
+type FileSummary struct {
+	FilePath string
+	// Information about the file, taken from its front matter
+	Metadata Metadata
+	// First and last day of the dated tasks within the file, zero if none
+	Start time.Time
+	End   time.Time
+
+	Entries        []SummaryEntry
+	TotalMins      int
+	NonWorkingMins int
+	InProgressMins int
+	// Number of problems found while parsing the file
+	CountDiagnostics int
+}
+
+// Summary of the tasks within a section, that has expectations of its own
+type ScopeSummary struct {
+	// Path of the file containing the section
+	FilePath string
+	// Text of the heading opening the section (e.g. "Week 41")
+	Heading string
+	// 1-based line number of the heading
+	Line int
+	// 1-based line number of the heading of the enclosing scope, zero if there is none
+	// Enclosing sections always have expectations, at least rolled up ones.
+	ParentLine int
+	// First and last day of the dated tasks within the section, zero if none
+	Start time.Time
+	End   time.Time
+
+	Entries        []SummaryEntry
+	TotalMins      int
+	NonWorkingMins int
+}
+
+// Information about a timebook, read from its YAML front matter
+type Metadata struct {
+	// The person the timebook belongs to
+	Person string `yaml:"person"`
+	// First and last day the timebook covers, zero if unknown
+	PeriodStart time.Time `yaml:"periodStart"`
+	PeriodEnd   time.Time `yaml:"periodEnd"`
+	// Contracted working hours per week, zero if unknown
+	WeeklyHours float64 `yaml:"weeklyHours"`
+	// Language of task and category names, if none is chosen in the settings
+	Locale Language `yaml:"locale"`
+	// Task and category definitions merged into the configured taxonomy
+	Taxonomy *Taxonomy `yaml:"taxonomy"`
+}
+
+// A time range logged by two tasks
+type Overlap struct {
+	// Path of the file logging both tasks
+	FilePath string
+	// The day of the later task
+	Date time.Time
+	// 1-based line numbers of the later and the earlier task
+	Line      int
+	OtherLine int
+	// The overlapping range, normalized to "HH:MM"
+	StartTime string
+	EndTime   string
+	// Duration of the overlapping range in minutes
+	DurationMins int
+}
+
+// Severity of a diagnostic
+type Severity string
+
+const (
+	// The line was skipped and is missing in the summary
+	SeverityError Severity = "error"
+	// The line was skipped, but may not be meant as timebook content
+	SeverityWarning Severity = "warning"
+)
+
+// A problem found while parsing a line of the timebook
+type Diagnostic struct {
+	// Path of the timebook file
+	FilePath string
+	// 1-based line number within the timebook file
+	Line int
+	// 1-based column within the line, zero if unknown
+	Column   int
+	Severity Severity
+	Message  string
+	// The original line as written in the timebook
+	Text string
+}
+
+// A time range within a day, that is not covered by any task
+type Gap struct {
+	// Path of the file logging the tasks around the gap
+	FilePath string
+	// The day of the gap
+	Date time.Time
+	// 1-based line numbers of the tasks before and after the gap
+	LineBefore int
+	LineAfter  int
+	// The range of the gap, normalized to "HH:MM"
+	StartTime string
+	EndTime   string
+	// Duration of the gap in minutes
+	DurationMins int
+	// Minutes of the gap not covered by the lunch break allowance
+	UnloggedMins int
+}
+
+// A single task as logged in the timebook
+type TaskEntry struct {
+	// Path of the timebook file
+	FilePath string
+	// 1-based line number within the timebook file
+	Line int
+	// The task short code (e.g. "A" for planned work)
+	TaskShort TaskShort
+	// The sub type of a two-level task short (e.g. "m" for "Mm")
+	SubType string
+	// The day of the task, taken from the closest preceding date heading
+	// If no date heading precedes the task, this is the zero time.
+	Date time.Time
+	// Start and end time normalized to "HH:MM" (e.g. "09:00")
+	// Running tasks have no end time.
+	StartTime string
+	EndTime   string
+	// Start and end as minutes since midnight
+	StartMins int
+	EndMins   int
+	// Whether the task has no end yet and is still running
+	Running bool
+	// Whether the task was logged with a duration only, without start and end
+	// Such tasks are counted, but not placed on a timeline.
+	DurationOnly bool
+	// Whether the task ends on the day after its start
+	EndsNextDay bool
+	// Whether the task is a break or other non-working time
+	// Such tasks are not part of worked totals and factors.
+	NonWorking bool
+	// Duration of the task in minutes
+	// NOTE: The time of child tasks is already subtracted.
+	DurationMins int
+	// 1-based line number of the enclosing task, zero for top-level tasks
+	ParentLine int
+	// Free text after the time range
+	// Child tasks without description inherit the one of their parent.
+	Description string
+	// Ticket references found in the description (e.g. "JIRA-123")
+	Tickets []string
+	// Tags found in the description, without leading "#" (e.g. "backend")
+	Tags []string
+	// Text of list items nested below the task, that are no tasks themselves
+	Notes []string
 }
 
 // A summary entry for a specific task
 type SummaryEntry struct {
 	// The task short code (e.g. "A" for planned work)
 	TaskShort TaskShort
+	// The sub type of a two-level task short (e.g. "m" for "Mm")
+	// Empty for entries summing up the whole task short.
+	SubType string
 	// The full name of the task (e.g. "Planned Work")
 	TaskName string
 	// The category short code (e.g. "M" for meetings)
 	CategoryShort CategoryShort
 	// The full name of the category (e.g. "Meetings")
 	CategoryName string
+	// Whether the task is a break or other non-working time
+	// If set, FactorOfTotal is always zero.
+	NonWorking bool
 	// Number of tasks for this entry
 	CountTasks int
 
@@ -33,6 +219,126 @@ type SummaryEntry struct {
 	// NOTE: This is a factor calculated over all tasks in the timebook.
 	// NOTE: This is a factor between 0 and 1, not a percentage.
 	FactorOfTotal float64
+
+	// Entries per sub type of this task short, ordered by sub type
+	// NOTE: Sub entries are already included in the numbers of this entry.
+	SubEntries []SummaryEntry
+}
+
+// A summary entry for a whole category, rolled up from its tasks
+type CategorySummaryEntry struct {
+	// The category short code (e.g. "M" for meetings)
+	CategoryShort CategoryShort
+	// The full name of the category (e.g. "Meetings")
+	CategoryName string
+	// Whether the category holds breaks or other non-working time
+	// If set, FactorOfTotal is always zero.
+	NonWorking bool
+	// The task short codes rolled up into this category
+	TaskShorts []TaskShort
+	// Number of tasks for this category
+	CountTasks int
+
+	// Minutes expected for all tasks of this category
+	// If zero, no expectation is set
+	ExpectedMinutes int
+	// Minutes actually received for all tasks of this category
+	ReceivedMinutes int
+
+	// Factor of received minutes to expected minutes
+	// If ExpectedMinutes is zero, this will also be zero.
+	// NOTE: This is a factor between 0 and 1, not a percentage.
+	FactorOfExpected float64
+	// Factor of received minutes to total minutes
+	// NOTE: This is a factor calculated over all tasks in the timebook.
+	// NOTE: This is a factor between 0 and 1, not a percentage.
+	FactorOfTotal float64
+}
+
+// A summary entry for a single ticket or tag
+type LabelSummaryEntry struct {
+	// The ticket reference (e.g. "JIRA-123") or tag (e.g. "backend")
+	Label string
+	// Number of tasks referencing this label
+	CountTasks int
+	// Minutes received by all tasks referencing this label
+	ReceivedMinutes int
+	// Factor of received minutes to total minutes
+	// NOTE: A task may reference multiple labels, so the factors of all
+	// labels may add up to more than 1.
+	FactorOfTotal float64
+}
+
+// Changes between two timebooks, e.g. of two consecutive months
+type Comparison struct {
+	// Worked minutes of the previous and the current timebook
+	PreviousTotalMins int
+	CurrentTotalMins  int
+	DeltaTotalMins    int
+
+	// Changes per task short and per category, in the order of the taxonomy
+	// Only task shorts and categories logged in either timebook are included.
+	Entries         []ComparisonEntry
+	CategoryEntries []CategoryComparisonEntry
+
+	// Task shorts logged in the current timebook only
+	NewTaskShorts []TaskShort
+	// Task shorts logged in the previous timebook only
+	DisappearedTaskShorts []TaskShort
+}
+
+// Change of a single task short between two timebooks
+type ComparisonEntry struct {
+	// The task short code (e.g. "A" for planned work)
+	TaskShort TaskShort
+	// The full name of the task (e.g. "Planned Work")
+	TaskName string
+	// The category short code (e.g. "M" for meetings)
+	CategoryShort CategoryShort
+	// Whether the task is a break or other non-working time
+	// If set, all factors are zero.
+	NonWorking bool
+
+	// Minutes received in the previous and the current timebook
+	PreviousMinutes int
+	CurrentMinutes  int
+	DeltaMinutes    int
+
+	// Factors of received minutes to total minutes and their difference
+	// NOTE: These are factors between 0 and 1, not percentages.
+	PreviousFactorOfTotal float64
+	CurrentFactorOfTotal  float64
+	DeltaFactorOfTotal    float64
+
+	// Whether the task short was logged in the current or the previous timebook only
+	New         bool
+	Disappeared bool
+}
+
+// Change of a whole category between two timebooks
+type CategoryComparisonEntry struct {
+	// The category short code (e.g. "M" for meetings)
+	CategoryShort CategoryShort
+	// The full name of the category (e.g. "Meetings")
+	CategoryName string
+	// Whether the category holds breaks or other non-working time
+	// If set, all factors are zero.
+	NonWorking bool
+
+	// Minutes received in the previous and the current timebook
+	PreviousMinutes int
+	CurrentMinutes  int
+	DeltaMinutes    int
+
+	// Factors of received minutes to total minutes and their difference
+	// NOTE: These are factors between 0 and 1, not percentages.
+	PreviousFactorOfTotal float64
+	CurrentFactorOfTotal  float64
+	DeltaFactorOfTotal    float64
+
+	// Whether the category was logged in the current or the previous timebook only
+	New         bool
+	Disappeared bool
 }
 
 // A task short code (e.g. "A" for planned work)
@@ -46,50 +352,10 @@ const (
 	Support       TaskShort = "S"
 	Maintenance   TaskShort = "W"
 	Miscellaneous TaskShort = "V"
+	Break         TaskShort = "P"
 )
 
-func (t TaskShort) FullName() string {
-	switch t {
-	case PlannedWork:
-		return "Geplante Arbeiten"
-	case UnplannedWork:
-		return "Ungeplante Arbeiten"
-	case Deployments:
-		return "Deployments"
-	case Meetings:
-		return "Meetings"
-	case Support:
-		return "Support"
-	case Maintenance:
-		return "Wartung"
-	case Miscellaneous:
-		return "Verschiedenes"
-	default:
-		return "Unbekannt"
-	}
-}
-
-func (t TaskShort) Category() CategoryShort {
-	switch t {
-	case PlannedWork:
-		return PlannedWorkCategory
-	case UnplannedWork:
-		return UnplannedWorkCategory
-	case Deployments:
-		return MaintenanceCategory
-	case Meetings:
-		return MeetingsCategory
-	case Support:
-		return SupportCategory
-	case Maintenance:
-		return MaintenanceCategory
-	case Miscellaneous:
-		return MiscellaneousCategory
-	default:
-		return MiscellaneousCategory
-	}
-}
-
+// A category short code (e.g. "M" for meetings)
 type CategoryShort string
 
 const (
@@ -99,23 +365,89 @@ const (
 	MaintenanceCategory   CategoryShort = "W"
 	SupportCategory       CategoryShort = "S"
 	MiscellaneousCategory CategoryShort = "V"
+	BreakCategory         CategoryShort = "P"
+)
+
+// A period of time to group timebook entries by
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
 )
 
-func (t CategoryShort) FullName() string {
-	switch t {
-	case PlannedWorkCategory:
-		return "Geplante Arbeiten"
-	case UnplannedWorkCategory:
-		return "Ungeplante Arbeiten"
-	case MeetingsCategory:
-		return "Meetings"
-	case MaintenanceCategory:
-		return "Wartung"
-	case SupportCategory:
-		return "Support"
-	case MiscellaneousCategory:
-		return "Verschiedenes"
-	default:
-		return "Unbekannt"
-	}
+// A key to sort summary entries by
+type SortKey string
+
+const (
+	SortByTaxonomy         SortKey = "taxonomy"
+	SortByReceivedMinutes  SortKey = "receivedMinutes"
+	SortByExpectedMinutes  SortKey = "expectedMinutes"
+	SortByFactorOfExpected SortKey = "factorOfExpected"
+	SortByFactorOfTotal    SortKey = "factorOfTotal"
+	SortByName             SortKey = "name"
+)
+
+// Summary of timebook entries within a single period
+// NOTE: Expectations are taken from the files and scopes, whose dated tasks lie
+// within the period. Expectations of parts spanning multiple periods are left out.
+// NOTE: FactorOfTotal of the entries is calculated over the period only.
+type PeriodSummary struct {
+	// The key of the period (e.g. "2025-10-09", "2025-W41" or "2025-10")
+	Period string
+	// First and last day of the period
+	Start time.Time
+	End   time.Time
+
+	Entries        []SummaryEntry
+	TotalMins      int
+	NonWorkingMins int
+}
+
+// Mapping of task shorts to their names and categories
+type Taxonomy struct {
+	Tasks      []TaskDefinition     `yaml:"tasks"`
+	Categories []CategoryDefinition `yaml:"categories"`
+	// Task short used for unknown task shorts
+	FallbackTaskShort TaskShort `yaml:"fallbackTaskShort"`
+}
+
+// Definition of a single task short
+type TaskDefinition struct {
+	// Single letter task short (e.g. "R" for reviews)
+	Short TaskShort `yaml:"short"`
+	// The full name of the task (e.g. "Reviews")
+	Name string `yaml:"name"`
+	// The full names of the task per language, preferred over Name
+	Names map[Language]string `yaml:"names"`
+	// The category the task belongs to
+	Category CategoryShort `yaml:"category"`
+}
+
+// Definition of a single category
+type CategoryDefinition struct {
+	// Single letter category short (e.g. "M" for meetings)
+	Short CategoryShort `yaml:"short"`
+	// The full name of the category (e.g. "Meetings")
+	Name string `yaml:"name"`
+	// The full names of the category per language, preferred over Name
+	Names map[Language]string `yaml:"names"`
+	// Whether tasks of the category are breaks or other non-working time
+	NonWorking bool `yaml:"nonWorking"`
+}
+
+// User settings, persisted in the user config directory
+type Settings struct {
+	// Language of task and category names
+	// If empty, the default language is used.
+	Language Language
+	// Whether time logged by overlapping tasks counts once only in TotalMins
+	// NOTE: Received minutes of single entries still include overlaps.
+	CountWallClockOnly bool
+	// Shortest gap between tasks to report, in minutes
+	// If zero, a default of 15 minutes is used.
+	MinGapMins int
+	// Minutes of the lunch gap per day, that are allowed as lunch break
+	LunchBreakMins int
 }
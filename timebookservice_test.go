@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Parse timebook content through a file, as loading it does
+func parseContent(t *testing.T, content string) TimebookSummary {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "timebook.md")
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := (&TimebookService{}).parseFile(filePath)
+	if err != nil {
+		t.Fatalf("parseFile() error = %v; want nil", err)
+	}
+
+	return summary
+}
+
+func TestParseFileRunningTaskOfPastDay(t *testing.T) {
+	weekAgo := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	summary := parseContent(t, fmt.Sprintf("## %s\n- (A 9:00 - )\n", weekAgo))
+
+	if len(summary.InProgress) != 1 || summary.InProgress[0].DurationMins != 15*60 {
+		t.Fatalf("InProgress = %+v; want one task running until the end of its day", summary.InProgress)
+	}
+	if summary.InProgressMins != 0 {
+		t.Errorf("InProgressMins = %d; want 0", summary.InProgressMins)
+	}
+	if len(summary.Diagnostics) != 1 || summary.Diagnostics[0].Severity != SeverityWarning {
+		t.Errorf("Diagnostics = %+v; want one warning", summary.Diagnostics)
+	}
+}
+
+func TestParseFileNesting(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		expectedTotalMins int
+		expectedParents   []int
+		expectedOverlaps  int
+	}{
+		{
+			name:              "Child refines its parent",
+			content:           "## 2025-10-09\n- (A 9:00 - 10:00)\n  - (M 9:00 - 9:30)\n",
+			expectedTotalMins: 60,
+			expectedParents:   []int{0, 2},
+		},
+		{
+			name:              "Blank line within a loose list",
+			content:           "## 2025-10-09\n- (A 9:00 - 10:00)\n\n  - (M 9:00 - 9:30)\n",
+			expectedTotalMins: 60,
+			expectedParents:   []int{0, 2},
+		},
+		{
+			name:              "Paragraph ends the list",
+			content:           "## 2025-10-09\n- (A 9:00 - 10:00)\n\nAfternoon\n  - (M 9:00 - 9:30)\n",
+			expectedTotalMins: 90,
+			expectedParents:   []int{0, 0},
+			expectedOverlaps:  1,
+		},
+		{
+			name:              "Date heading ends the list",
+			content:           "## 2025-10-09\n- (A 9:00 - 10:00)\n## 2025-10-10\n  - (M 9:00 - 9:30)\n",
+			expectedTotalMins: 90,
+			expectedParents:   []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := parseContent(t, tt.content)
+
+			if summary.TotalMins != tt.expectedTotalMins {
+				t.Errorf("TotalMins = %d; want %d", summary.TotalMins, tt.expectedTotalMins)
+			}
+			parents := make([]int, 0)
+			for _, task := range summary.Tasks {
+				parents = append(parents, task.ParentLine)
+			}
+			if !reflect.DeepEqual(parents, tt.expectedParents) {
+				t.Errorf("parent lines = %v; want %v", parents, tt.expectedParents)
+			}
+			if len(summary.Overlaps) != tt.expectedOverlaps {
+				t.Errorf("Overlaps = %+v; want %d", summary.Overlaps, tt.expectedOverlaps)
+			}
+		})
+	}
+}
+
+// Loading time per line stays the same for growing archives
+func BenchmarkParseFile(b *testing.B) {
+	for _, lineCount := range []int{1_000, 10_000, 100_000, 300_000} {
+		var builder strings.Builder
+		for i := 0; i < lineCount; i++ {
+			switch i % 10 {
+			case 0:
+				fmt.Fprintf(&builder, "## %s\n", time.Date(2000, 1, 1+i/10, 0, 0, 0, 0, time.UTC).Format("2006-01-02"))
+			case 1:
+				builder.WriteString("> - Planned Work A: 8h\n")
+			case 9:
+				builder.WriteString("  - (M 30m) discussed the rollout\n")
+			default:
+				fmt.Fprintf(&builder, "- (A %d:00 - %d:30) Fix login bug JIRA-%d #backend\n", i%10+7, i%10+7, i)
+			}
+		}
+
+		filePath := filepath.Join(b.TempDir(), "timebook.md")
+		if err := os.WriteFile(filePath, []byte(builder.String()), 0o644); err != nil {
+			b.Fatal(err)
+		}
+
+		b.Run(fmt.Sprintf("lines=%d", lineCount), func(b *testing.B) {
+			b.SetBytes(int64(builder.Len()))
+			for i := 0; i < b.N; i++ {
+				if _, err := (&TimebookService{}).parseFile(filePath); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*lineCount), "ns/line")
+		})
+	}
+}
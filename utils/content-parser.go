@@ -1,9 +1,14 @@
 package utils
 
 import (
-	"log"
+	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type RawTask struct {
@@ -11,13 +16,41 @@ type RawTask struct {
 	TaskShort string
 	StartTime string
 	EndTime   string
+	// Free text after the closing parenthesis
+	Description string
+	// Duration of tasks logged without start and end (e.g. "45m")
+	Duration string
 }
 
 type ParsedTask struct {
-	TaskShort    string
-	StartTime    string
-	EndTime      string
+	TaskShort string
+	// Lower case second letter of a two-level task short (e.g. "m" for "Mm")
+	// Empty if the task short has a single letter only.
+	SubType string
+	// Start and end normalized to "HH:MM"
+	StartTime string
+	EndTime   string
+	// Start and end as minutes since midnight
+	StartMins    int
+	EndMins      int
 	DurationMins int
+	// Whether the task has no end yet and is still running
+	// Running tasks have no end time and a duration of zero.
+	Running bool
+	// Whether the task was logged with a duration only
+	// Such tasks have neither start nor end time.
+	DurationOnly bool
+	// Day of the task, taken from the closest preceding date heading.
+	// Zero if no date heading precedes the task.
+	Date time.Time
+	// Whether the task ends on the day after its start
+	EndsNextDay bool
+	// Free text after the closing parenthesis
+	Description string
+	// Ticket references found in the description (e.g. "JIRA-123")
+	Tickets []string
+	// Tags found in the description, without leading "#" (e.g. "backend")
+	Tags []string
 }
 
 type ParsedExpection struct {
@@ -26,21 +59,36 @@ type ParsedExpection struct {
 	DurationMins int
 }
 
+// Marker after an end time, to explicitly end a task on the next day
+// Example line: "- (S 22:00 - 11:00+1) Task description"
+const nextDayMarker = "+1"
+
+// Markers instead of an end time, for tasks that are still running
+var runningEndMarkers = []string{"…", "..."}
+
+// Longest task, that implicitly crosses midnight if its end is before its start.
+// Longer ones are considered to have a reversed range.
+const maxImplicitOvernightMins = 12 * 60
+
+// Ticket references like "JIRA-123" within a description
+var ticketPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// Tags like "#backend" within a description, starting with a letter
+var tagPattern = regexp.MustCompile(`(?:^|\s)#(\pL[\pL\pN_/-]*)`)
+
+// Layouts accepted for dates within a heading
+var dateHeadingLayouts = []string{
+	"2006-1-2",
+	"2.1.2006",
+}
+
 // Filter and trim lines for expected content
 func FilterAndTrimLines(lines []string) []string {
 	filteredLines := make([]string, 0)
 
 	for _, line := range lines {
-		// Trim spaces and tabs
-		trimmedLine := strings.Trim(line, string([]byte{' ', '\t'}))
-
-		// Ignore empty lines
-		if len(trimmedLine) == 0 {
-			continue
-		}
-
-		// Expected content starts with "- ("
-		if len(trimmedLine) < 3 || trimmedLine[0:3] != "- (" {
+		trimmedLine, ok := FilterAndTrimLine(line)
+		if !ok {
 			continue
 		}
 
@@ -50,47 +98,155 @@ func FilterAndTrimLines(lines []string) []string {
 	return filteredLines
 }
 
+// Trim a single line and check it for expected content
+// Returns the trimmed line and true, if the line contains a task entry.
+func FilterAndTrimLine(line string) (string, bool) {
+	// Trim spaces and tabs
+	trimmedLine := strings.Trim(line, string([]byte{' ', '\t'}))
+
+	// Ignore empty lines
+	if len(trimmedLine) == 0 {
+		return "", false
+	}
+
+	// Expected content starts with "- ("
+	if len(trimmedLine) < 3 || trimmedLine[0:3] != "- (" {
+		return "", false
+	}
+
+	return trimmedLine, true
+}
+
+// Width of a tab when measuring the indentation of a line
+const tabIndentWidth = 4
+
+// Bullet markers of markdown list items
+var bulletMarkers = []string{"- ", "* "}
+
+// Measure the indentation of a line, tabs count as 4 spaces
+func IndentWidth(line string) int {
+	width := 0
+	for _, char := range line {
+		switch char {
+		case ' ':
+			width++
+		case '\t':
+			width += tabIndentWidth
+		default:
+			return width
+		}
+	}
+
+	return width
+}
+
+// Parse a list item without task entry, used as note to its parent task
+// Example line: "  - discussed the rollout"
+// Returns the text of the note and true, if the line is such a list item.
+func ParseNoteLine(line string) (string, bool) {
+	if _, ok := FilterAndTrimLine(line); ok {
+		return "", false
+	}
+
+	trimmedLine := strings.TrimSpace(line)
+	for _, marker := range bulletMarkers {
+		if note, ok := strings.CutPrefix(trimmedLine, marker); ok && strings.TrimSpace(note) != "" {
+			return strings.TrimSpace(note), true
+		}
+	}
+
+	return "", false
+}
+
+// Parse a markdown heading to its level and text
+// Example line: "## Week 41" results in level 2 and text "Week 41"
+func ParseHeading(line string) (int, string, bool) {
+	trimmedLine := strings.TrimSpace(line)
+
+	level := 0
+	for level < len(trimmedLine) && trimmedLine[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+
+	// "#tag" is no heading, the marker must be followed by a space
+	text := trimmedLine[level:]
+	if text != "" && text[0] != ' ' && text[0] != '\t' {
+		return 0, "", false
+	}
+
+	return level, strings.TrimSpace(text), true
+}
+
+// Parse a markdown heading to extract the day it stands for
+// Example line: "## 2025-10-09"
+// Example line: "## Do, 09.10.2025"
+// Example line: "### Thursday 9.10.2025 (home office)"
+// Lines like "#release 2025-10-20" are no headings and carry no day.
+func ParseDateHeading(line string) (time.Time, bool) {
+	_, text, ok := ParseHeading(line)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	// The first word that parses as date wins
+	for _, field := range strings.Fields(text) {
+		field = strings.Trim(field, ",;:()[]")
+
+		for _, layout := range dateHeadingLayouts {
+			date, err := time.Parse(layout, field)
+			if err == nil {
+				return date, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
 // Parse expected line to extract expected task information
+// Returns ErrNoExpectionLine for lines not shaped like an expection.
 // Example line: "> - Task Long A: 178h"
 // Example line: "> - Task Long W: 20h"
 // Example line: "> - Another Task Long M: 20h"
-func ParseExpectionLine(line string) (*ParsedExpection, bool) {
+// Example line: "> - Part Time Task Long A: 7,5h"
+func ParseExpectionLine(line string) (*ParsedExpection, error) {
 	// if line does not start with "> - ", ignore
 	if len(line) < 4 || line[0:4] != "> - " {
-		return nil, false
+		return nil, ErrNoExpectionLine
 	}
 
 	// Find the position of the colon
 	colonIndex := strings.Index(line, ":")
 	if colonIndex == -1 {
-		return nil, false
+		return nil, ErrNoExpectionLine
 	}
 
 	// Task short is the last character before the colon
-	taskPart := strings.TrimSpace(line[colonIndex-1 : colonIndex])
+	_, taskPartSize := utf8.DecodeLastRuneInString(line[:colonIndex])
+	taskPart := strings.TrimSpace(line[colonIndex-taskPartSize : colonIndex])
 	if len(taskPart) == 0 {
-		return nil, false
+		return nil, newParseError(colonIndex+1, "missing task short before colon")
 	}
 
-	// Duration is the part after the colon, trim spaces and "h"
+	// Duration is the part after the colon
 	durationPart := strings.TrimSpace(line[colonIndex+1:])
-	durationPart = strings.TrimSuffix(durationPart, "h")
 	if len(durationPart) == 0 {
-		return nil, false
+		return nil, newParseError(colonIndex+2, "missing duration after colon")
 	}
 
-	durationHours, err := strconv.Atoi(durationPart)
-	if err != nil || durationHours < 0 {
-		return nil, false
+	durationMins, ok := ParseDurationToMins(durationPart)
+	if !ok {
+		return nil, newParseError(columnOf(line, durationPart, colonIndex+2), "invalid duration %q", durationPart)
 	}
 
-	log.Printf("Parsed expection line: taskShort=%s, durationHours=%d", taskPart, durationHours)
-
 	return &ParsedExpection{
 		Line:         line,
 		TaskShort:    strings.ToUpper(taskPart),
-		DurationMins: durationHours * 60,
-	}, true
+		DurationMins: durationMins,
+	}, nil
 }
 
 // Parse task line to extract task short, start and end time
@@ -98,11 +254,16 @@ func ParseExpectionLine(line string) (*ParsedExpection, bool) {
 // Example line: "- (Mm 1:23 - 4:56) Task description"
 // Example line: "- (V 11:23 - 14:56) Task description"
 // Example line: "- (V 01:23 - 04:56) Task description"
-func ParseTaskLine(line string) (*RawTask, bool) {
+// Example line: "- (A 9:00 - 10:00) Fix login bug JIRA-123 #backend"
+// Example line: "- (A 14:05 - ) Running task description"
+// Example line: "- (A 14:05 - …) Running task description"
+// Example line: "- (S 45m) Duration only task description"
+// Example line: "- (A 1h30) Duration only task description"
+func ParseTaskLine(line string) (*RawTask, error) {
 	// Find the position of the closing parenthesis
 	closeParenIndex := strings.Index(line, ")")
 	if closeParenIndex == -1 {
-		return nil, false
+		return nil, newParseError(len(line)+1, "missing closing parenthesis")
 	}
 
 	// Extract the content within the parentheses
@@ -110,57 +271,264 @@ func ParseTaskLine(line string) (*RawTask, bool) {
 
 	// Split the content by spaces
 	// Should result in 4 parts: [TaskShort, StartTime, "-", EndTime]
-	parts := strings.Fields(parenContent)
+	// Running tasks have no EndTime or an ellipsis instead.
+	parts := splitTaskParts(parenContent)
+
+	// Tasks without time range have 2 parts only: [TaskShort, Duration]
+	if len(parts) == 2 && isDurationOnly(parts[1]) {
+		return &RawTask{
+			Line:        line,
+			TaskShort:   parts[0],
+			Duration:    parts[1],
+			Description: strings.TrimSpace(line[closeParenIndex+1:]),
+		}, nil
+	}
+
+	if len(parts) == 3 && parts[2] == "-" {
+		parts = append(parts, "")
+	}
 	if len(parts) < 4 {
-		return nil, false
+		return nil, newParseError(4, "expected \"(<task short> <start> - <end>)\", got %q", parenContent)
+	}
+
+	// parts after the end time would get lost
+	if len(parts) > 4 {
+		column := 4
+		for _, part := range parts[:4] {
+			column = columnOf(line, part, column) + len(part)
+		}
+		extra := strings.Join(parts[4:], " ")
+		return nil, newParseError(columnOf(line, parts[4], column), "unexpected %q after the end time", extra)
+	}
+
+	endTime := parts[3]
+	if slices.Contains(runningEndMarkers, endTime) {
+		endTime = ""
 	}
 
 	return &RawTask{
-		Line:      line,
-		TaskShort: parts[0],
-		StartTime: parts[1],
-		EndTime:   parts[3],
-	}, true
+		Line:        line,
+		TaskShort:   parts[0],
+		StartTime:   parts[1],
+		EndTime:     endTime,
+		Description: strings.TrimSpace(line[closeParenIndex+1:]),
+	}, nil
+}
+
+// Split the content within the parentheses of a task line into its parts
+// The dash of a time range needs no spaces around it.
+// Example content: "A 9:00-10:00" results in ["A", "9:00", "-", "10:00"]
+func splitTaskParts(parenContent string) []string {
+	parts := strings.Fields(parenContent)
+	if len(parts) == 0 {
+		return parts
+	}
+
+	// the task short comes first and is kept as is
+	rest := parenContent[strings.Index(parenContent, parts[0])+len(parts[0]):]
+	start, end, found := strings.Cut(rest, "-")
+	if !found {
+		return parts
+	}
+
+	parts = append(parts[:1], strings.Fields(start)...)
+	parts = append(parts, "-")
+	return append(parts, strings.Fields(end)...)
 }
 
 // Convert RawTask to ParsedTask
-func ConvertRawToParsed(raw *RawTask) (*ParsedTask, bool) {
+func ConvertRawToParsed(raw *RawTask) (*ParsedTask, error) {
 	if len(raw.TaskShort) == 0 {
-		return nil, false
+		return nil, newParseError(4, "missing task short")
+	}
+
+	// codes may be any letters, so split them by characters instead of bytes
+	codeRunes := []rune(raw.TaskShort)
+	taskShort := string(unicode.ToUpper(codeRunes[0]))
+	subType := ""
+	if len(codeRunes) > 1 {
+		subType = string(unicode.ToLower(codeRunes[1]))
+	}
+
+	// Tasks without time range count their duration only
+	if raw.Duration != "" {
+		durationMins, ok := ParseDurationToMins(raw.Duration)
+		if !ok {
+			return nil, newParseError(columnOf(raw.Line, raw.Duration, 4), "invalid duration %q", raw.Duration)
+		}
+
+		return &ParsedTask{
+			TaskShort:    taskShort,
+			SubType:      subType,
+			DurationMins: durationMins,
+			DurationOnly: true,
+			Description:  raw.Description,
+			Tickets:      extractTickets(raw.Description),
+			Tags:         extractTags(raw.Description),
+		}, nil
+	}
+
+	startColumn := columnOf(raw.Line, raw.StartTime, 4)
+	startMins, ok := parseTimeStringToMins(raw.StartTime)
+	if !ok {
+		return nil, newParseError(startColumn, "invalid start time %q", raw.StartTime)
 	}
-	taskShort := strings.ToUpper(raw.TaskShort)
 
-	startMins, ok1 := parseTimeStringToMins(raw.StartTime)
-	endMins, ok2 := parseTimeStringToMins(raw.EndTime)
-	if !ok1 || !ok2 {
-		return nil, false
+	// Running tasks have no end yet, their duration depends on the current time
+	if raw.EndTime == "" {
+		return &ParsedTask{
+			TaskShort:   taskShort,
+			SubType:     subType,
+			StartTime:   formatMinsAsTime(startMins),
+			StartMins:   startMins,
+			Running:     true,
+			Description: raw.Description,
+			Tickets:     extractTickets(raw.Description),
+			Tags:        extractTags(raw.Description),
+		}, nil
 	}
 
+	endColumn := columnOf(raw.Line, raw.EndTime, startColumn+len(raw.StartTime))
+	endTime, endsNextDay := strings.CutSuffix(raw.EndTime, nextDayMarker)
+	endMins, ok := parseTimeStringToMins(endTime)
+	if !ok {
+		return nil, newParseError(endColumn, "invalid end time %q", raw.EndTime)
+	}
+
+	// An end before the start crosses midnight, unless the task would get too long
 	durationMins := endMins - startMins
-	if durationMins < 0 {
-		durationMins = durationMins * -1
+	if durationMins < 0 && !endsNextDay {
+		if durationMins+24*60 > maxImplicitOvernightMins {
+			return nil, newParseError(endColumn, "end time %q is before start time %q, append %q if the task ends on the next day", raw.EndTime, raw.StartTime, nextDayMarker)
+		}
+		endsNextDay = true
+	}
+	if endsNextDay {
+		durationMins += 24 * 60
 	}
 
 	return &ParsedTask{
-		TaskShort:    taskShort[:1],
-		StartTime:    raw.StartTime,
-		EndTime:      raw.EndTime,
+		TaskShort:    taskShort,
+		SubType:      subType,
+		StartTime:    formatMinsAsTime(startMins),
+		EndTime:      formatMinsAsTime(endMins),
+		StartMins:    startMins,
+		EndMins:      endMins,
 		DurationMins: durationMins,
-	}, true
+		EndsNextDay:  endsNextDay,
+		Description:  raw.Description,
+		Tickets:      extractTickets(raw.Description),
+		Tags:         extractTags(raw.Description),
+	}, nil
+}
+
+// Check whether a part within parentheses is a duration instead of a time
+// Durations need a unit, as a bare "1:23" reads like an incomplete time range.
+func isDurationOnly(part string) bool {
+	if len(part) == 0 || !unicode.IsDigit(rune(part[0])) || strings.Contains(part, ":") {
+		return false
+	}
+
+	return strings.ContainsFunc(part, unicode.IsLetter)
+}
+
+// Extract unique ticket references in order of appearance
+func extractTickets(description string) []string {
+	var tickets []string
+	for _, ticket := range ticketPattern.FindAllString(description, -1) {
+		if !slices.Contains(tickets, ticket) {
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	return tickets
+}
+
+// Extract unique tags in order of appearance
+func extractTags(description string) []string {
+	var tags []string
+	for _, match := range tagPattern.FindAllStringSubmatch(description, -1) {
+		if !slices.Contains(tags, match[1]) {
+			tags = append(tags, match[1])
+		}
+	}
+
+	return tags
 }
 
+// Parse a clock time to minutes since midnight
+// Example time: "9:30", "09:30", "9.30", "0930", "9h30", "9h"
+// Example time: "2:15pm", "2pm", "12am"
+// Minutes always take two digits, as "9.5" is as likely meant to be 9:30 as 9:05.
 func parseTimeStringToMins(timeStr string) (int, bool) {
-	// Split the time string by ":"
-	parts := strings.Split(timeStr, ":")
-	if len(parts) != 2 {
+	timeStr = strings.ToLower(timeStr)
+
+	// Remember 12-hour clock suffix
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm"} {
+		if trimmed, found := strings.CutSuffix(timeStr, suffix); found {
+			meridiem = suffix
+			timeStr = trimmed
+		}
+	}
+
+	// Split into hours and minutes
+	var hoursPart, minutesPart string
+	if separatorIndex := strings.IndexAny(timeStr, ":.h"); separatorIndex != -1 {
+		hoursPart, minutesPart = timeStr[:separatorIndex], timeStr[separatorIndex+1:]
+		if minutesPart == "" && timeStr[separatorIndex] == 'h' {
+			minutesPart = "00"
+		}
+	} else if meridiem != "" {
+		hoursPart, minutesPart = timeStr, "00"
+	} else if len(timeStr) == 4 {
+		hoursPart, minutesPart = timeStr[:2], timeStr[2:]
+	} else {
+		return 0, false
+	}
+
+	if !isDigits(hoursPart) || !isDigits(minutesPart) || len(minutesPart) != 2 {
 		return 0, false
 	}
+	hours, _ := strconv.Atoi(hoursPart)
+	minutes, _ := strconv.Atoi(minutesPart)
+	if minutes >= 60 {
+		return 0, false
+	}
+
+	// 12-hour clock runs from 12am (midnight) to 11pm
+	if meridiem != "" {
+		if hours < 1 || hours > 12 {
+			return 0, false
+		}
+		hours = hours % 12
+		if meridiem == "pm" {
+			hours += 12
+		}
+	}
 
-	hours, err1 := strconv.Atoi(parts[0])
-	minutes, err2 := strconv.Atoi(parts[1])
-	if err1 != nil || err2 != nil || hours < 0 || minutes < 0 {
+	if hours >= 24 {
 		return 0, false
 	}
 
 	return hours*60 + minutes, true
 }
+
+// Format minutes since midnight as zero-padded "HH:MM"
+func formatMinsAsTime(mins int) string {
+	return fmt.Sprintf("%02d:%02d", mins/60, mins%60)
+}
+
+func isDigits(str string) bool {
+	if len(str) == 0 {
+		return false
+	}
+
+	for _, char := range str {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFilterAndTrimLines(t *testing.T) {
@@ -76,6 +78,337 @@ func TestFilterAndTrimLines(t *testing.T) {
 	}
 }
 
+func TestParseHeading(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedLevel int
+		expectedText  string
+		ok            bool
+	}{
+		{
+			name:          "Top-level heading",
+			input:         "# October 2025",
+			expectedLevel: 1,
+			expectedText:  "October 2025",
+			ok:            true,
+		},
+		{
+			name:          "Indented heading with trailing spaces",
+			input:         "  ## Week 41  ",
+			expectedLevel: 2,
+			expectedText:  "Week 41",
+			ok:            true,
+		},
+		{
+			name:          "Empty heading",
+			input:         "###",
+			expectedLevel: 3,
+			expectedText:  "",
+			ok:            true,
+		},
+		{
+			name:  "Tag instead of heading",
+			input: "#backend",
+			ok:    false,
+		},
+		{
+			name:  "Too many markers",
+			input: "####### Deep",
+			ok:    false,
+		},
+		{
+			name:  "No heading",
+			input: "- (A 9:00 - 10:00)",
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, text, ok := ParseHeading(tt.input)
+			if ok != tt.ok {
+				t.Errorf("ParseHeading(%q) ok = %v; want %v", tt.input, ok, tt.ok)
+			}
+			if level != tt.expectedLevel || text != tt.expectedText {
+				t.Errorf("ParseHeading(%q) = %d, %q; want %d, %q", tt.input, level, text, tt.expectedLevel, tt.expectedText)
+			}
+		})
+	}
+}
+
+func TestParseDateHeading(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "ISO date",
+			input:    "## 2025-10-09",
+			expected: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "German date with weekday",
+			input:    "## Do, 09.10.2025",
+			expected: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "German date without leading zeros",
+			input:    "# 9.10.2025",
+			expected: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Date followed by text and indented",
+			input:    "  ### Thursday 2025-10-09 (home office)",
+			expected: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Date in parentheses",
+			input:    "## Thursday (09.10.2025)",
+			expected: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:  "Heading without date",
+			input: "## Notes",
+			ok:    false,
+		},
+		{
+			name:  "Invalid date",
+			input: "## 2025-13-40",
+			ok:    false,
+		},
+		{
+			name:  "Date without heading",
+			input: "2025-10-09",
+			ok:    false,
+		},
+		{
+			name:  "Tag followed by date",
+			input: "#release shipped 2025-10-20",
+			ok:    false,
+		},
+		{
+			name:  "Date without space after markers",
+			input: "##2025-10-09",
+			ok:    false,
+		},
+		{
+			name:  "Empty string",
+			input: "",
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseDateHeading(tt.input)
+			if ok != tt.ok {
+				t.Errorf("ParseDateHeading(%q) ok = %v; want %v", tt.input, ok, tt.ok)
+			}
+			if !result.Equal(tt.expected) {
+				t.Errorf("ParseDateHeading(%q) = %v; want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIndentWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "No indentation", input: "- (A 9:00 - 10:00)", expected: 0},
+		{name: "Spaces", input: "  - (A 9:00 - 10:00)", expected: 2},
+		{name: "Tab", input: "\t- (A 9:00 - 10:00)", expected: 4},
+		{name: "Tab and spaces", input: "\t  - note", expected: 6},
+		{name: "Whitespace only", input: "   ", expected: 3},
+		{name: "Empty string", input: "", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IndentWidth(tt.input)
+			if result != tt.expected {
+				t.Errorf("IndentWidth(%q) = %d; want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseNoteLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "Indented dash bullet",
+			input:    "  - discussed the rollout",
+			expected: "discussed the rollout",
+			ok:       true,
+		},
+		{
+			name:     "Asterisk bullet",
+			input:    "\t* follow up with ops ",
+			expected: "follow up with ops",
+			ok:       true,
+		},
+		{
+			name:  "Task entry",
+			input: "  - (Mm 10:00 - 10:15) standup",
+			ok:    false,
+		},
+		{
+			name:  "Empty bullet",
+			input: "  - ",
+			ok:    false,
+		},
+		{
+			name:  "Plain text",
+			input: "  some text",
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseNoteLine(tt.input)
+			if ok != tt.ok {
+				t.Errorf("ParseNoteLine(%q) ok = %v; want %v", tt.input, ok, tt.ok)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseNoteLine(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseExpectionLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *ParsedExpection
+		column   int
+		err      error
+	}{
+		{
+			name:  "Valid expection line",
+			input: "> - Task Long A: 178h",
+			expected: &ParsedExpection{
+				Line:         "> - Task Long A: 178h",
+				TaskShort:    "A",
+				DurationMins: 178 * 60,
+			},
+		},
+		{
+			name:  "Lower case task short",
+			input: "> - Another Task Long m: 20h",
+			expected: &ParsedExpection{
+				Line:         "> - Another Task Long m: 20h",
+				TaskShort:    "M",
+				DurationMins: 20 * 60,
+			},
+		},
+		{
+			name:  "Non-ASCII task short",
+			input: "> - Übung ü: 2h",
+			expected: &ParsedExpection{
+				Line:         "> - Übung ü: 2h",
+				TaskShort:    "Ü",
+				DurationMins: 2 * 60,
+			},
+		},
+		{
+			name:  "Decimal comma duration",
+			input: "> - Part Time A: 7,5h",
+			expected: &ParsedExpection{
+				Line:         "> - Part Time A: 7,5h",
+				TaskShort:    "A",
+				DurationMins: 450,
+			},
+		},
+		{
+			name:  "Hours and minutes duration",
+			input: "> - Quarter W: 160h 30m",
+			expected: &ParsedExpection{
+				Line:         "> - Quarter W: 160h 30m",
+				TaskShort:    "W",
+				DurationMins: 160*60 + 30,
+			},
+		},
+		{
+			name:  "Clock notation duration",
+			input: "> - Daily M: 1:30",
+			expected: &ParsedExpection{
+				Line:         "> - Daily M: 1:30",
+				TaskShort:    "M",
+				DurationMins: 90,
+			},
+		},
+		{
+			name:  "No blockquote list",
+			input: "- Task Long A: 178h",
+			err:   ErrNoExpectionLine,
+		},
+		{
+			name:  "Blockquote list without colon",
+			input: "> - just a note",
+			err:   ErrNoExpectionLine,
+		},
+		{
+			name:   "Missing duration",
+			input:  "> - Task Long A:",
+			column: 17,
+		},
+		{
+			name:   "Invalid duration",
+			input:  "> - Task Long A: 17x8h",
+			column: 18,
+		},
+		{
+			name:   "Negative duration",
+			input:  "> - Task Long A: -1h",
+			column: 18,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseExpectionLine(tt.input)
+			if tt.expected != nil {
+				if err != nil || !reflect.DeepEqual(result, tt.expected) {
+					t.Errorf("ParseExpectionLine(%q) = %+v, %v; want %+v", tt.input, result, err, tt.expected)
+				}
+				return
+			}
+
+			if result != nil {
+				t.Errorf("ParseExpectionLine(%q) = %+v; want nil", tt.input, result)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("ParseExpectionLine(%q) err = %v; want %v", tt.input, err, tt.err)
+				}
+				return
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Column != tt.column {
+				t.Errorf("ParseExpectionLine(%q) err = %v; want parse error at column %d", tt.input, err, tt.column)
+			}
+		})
+	}
+}
+
 func TestParseLine(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -87,10 +420,11 @@ func TestParseLine(t *testing.T) {
 			name:  "Valid line with V short",
 			input: "- (V 1:23 - 4:56) Task description",
 			expected: &RawTask{
-				Line:      "- (V 1:23 - 4:56) Task description",
-				TaskShort: "V",
-				StartTime: "1:23",
-				EndTime:   "4:56",
+				Line:        "- (V 1:23 - 4:56) Task description",
+				TaskShort:   "V",
+				StartTime:   "1:23",
+				EndTime:     "4:56",
+				Description: "Task description",
 			},
 			ok: true,
 		},
@@ -98,10 +432,11 @@ func TestParseLine(t *testing.T) {
 			name:  "Valid line with Mm short and double digit times",
 			input: "- (Mm 11:23 - 14:56) Task description",
 			expected: &RawTask{
-				Line:      "- (Mm 11:23 - 14:56) Task description",
-				TaskShort: "Mm",
-				StartTime: "11:23",
-				EndTime:   "14:56",
+				Line:        "- (Mm 11:23 - 14:56) Task description",
+				TaskShort:   "Mm",
+				StartTime:   "11:23",
+				EndTime:     "14:56",
+				Description: "Task description",
 			},
 			ok: true,
 		},
@@ -109,13 +444,32 @@ func TestParseLine(t *testing.T) {
 			name:  "Valid line with leading zeros",
 			input: "- (V 01:23 - 04:56) Task description",
 			expected: &RawTask{
-				Line:      "- (V 01:23 - 04:56) Task description",
-				TaskShort: "V",
-				StartTime: "01:23",
-				EndTime:   "04:56",
+				Line:        "- (V 01:23 - 04:56) Task description",
+				TaskShort:   "V",
+				StartTime:   "01:23",
+				EndTime:     "04:56",
+				Description: "Task description",
+			},
+			ok: true,
+		},
+		{
+			name:  "Valid line with range without spaces",
+			input: "- (V 1:23-4:56)Task description",
+			expected: &RawTask{
+				Line:        "- (V 1:23-4:56)Task description",
+				TaskShort:   "V",
+				StartTime:   "1:23",
+				EndTime:     "4:56",
+				Description: "Task description",
 			},
 			ok: true,
 		},
+		{
+			name:     "Parts after the end time",
+			input:    "- (A 9:00 - 10:00 - 11:00) Task description",
+			expected: nil,
+			ok:       false,
+		},
 		{
 			name:     "Missing closing parenthesis",
 			input:    "- (V 1:23 - 4:56 Task description",
@@ -150,10 +504,75 @@ func TestParseLine(t *testing.T) {
 			name:  "Valid line with extra spaces",
 			input: "- (V   1:23   -   4:56 ) Task description",
 			expected: &RawTask{
-				Line:      "- (V   1:23   -   4:56 ) Task description",
-				TaskShort: "V",
-				StartTime: "1:23",
-				EndTime:   "4:56",
+				Line:        "- (V   1:23   -   4:56 ) Task description",
+				TaskShort:   "V",
+				StartTime:   "1:23",
+				EndTime:     "4:56",
+				Description: "Task description",
+			},
+			ok: true,
+		},
+		{
+			name:  "Valid line with ticket and tag",
+			input: "- (A 9:00 - 10:00) Fix login bug JIRA-123 #backend ",
+			expected: &RawTask{
+				Line:        "- (A 9:00 - 10:00) Fix login bug JIRA-123 #backend ",
+				TaskShort:   "A",
+				StartTime:   "9:00",
+				EndTime:     "10:00",
+				Description: "Fix login bug JIRA-123 #backend",
+			},
+			ok: true,
+		},
+		{
+			name:  "Duration only line with minutes",
+			input: "- (S 45m) answered tickets",
+			expected: &RawTask{
+				Line:        "- (S 45m) answered tickets",
+				TaskShort:   "S",
+				Duration:    "45m",
+				Description: "answered tickets",
+			},
+			ok: true,
+		},
+		{
+			name:  "Duration only line with hours and minutes",
+			input: "- (A 1h30) spec review",
+			expected: &RawTask{
+				Line:        "- (A 1h30) spec review",
+				TaskShort:   "A",
+				Duration:    "1h30",
+				Description: "spec review",
+			},
+			ok: true,
+		},
+		{
+			name:     "Duration only line without unit",
+			input:    "- (S 45) answered tickets",
+			expected: nil,
+			ok:       false,
+		},
+		{
+			name:  "Running line without end time",
+			input: "- (A 14:05 - ) working on release",
+			expected: &RawTask{
+				Line:        "- (A 14:05 - ) working on release",
+				TaskShort:   "A",
+				StartTime:   "14:05",
+				EndTime:     "",
+				Description: "working on release",
+			},
+			ok: true,
+		},
+		{
+			name:  "Running line with ellipsis",
+			input: "- (A 14:05 - …) working on release",
+			expected: &RawTask{
+				Line:        "- (A 14:05 - …) working on release",
+				TaskShort:   "A",
+				StartTime:   "14:05",
+				EndTime:     "",
+				Description: "working on release",
 			},
 			ok: true,
 		},
@@ -172,7 +591,8 @@ func TestParseLine(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, ok := ParseTaskLine(tt.input)
+			result, err := ParseTaskLine(tt.input)
+			ok := err == nil
 			if ok != tt.ok {
 				t.Errorf("ParseLine(%q) ok = %v; want %v", tt.input, ok, tt.ok)
 			}
@@ -203,14 +623,16 @@ func TestConvertRawToParsed(t *testing.T) {
 			},
 			expected: &ParsedTask{
 				TaskShort:    "V",
-				StartTime:    "1:23",
-				EndTime:      "4:56",
+				StartTime:    "01:23",
+				EndTime:      "04:56",
+				StartMins:    83,
+				EndMins:      296,
 				DurationMins: 213,
 			},
 			ok: true,
 		},
 		{
-			name: "Valid multi-letter TaskShort (should use first letter uppercased)",
+			name: "Valid multi-letter TaskShort (should use first letter uppercased and keep sub type)",
 			input: &RawTask{
 				Line:      "- (mm 11:23 - 14:56) Task description",
 				TaskShort: "mm",
@@ -219,12 +641,133 @@ func TestConvertRawToParsed(t *testing.T) {
 			},
 			expected: &ParsedTask{
 				TaskShort:    "M",
+				SubType:      "m",
 				StartTime:    "11:23",
 				EndTime:      "14:56",
+				StartMins:    683,
+				EndMins:      896,
 				DurationMins: 213,
 			},
 			ok: true,
 		},
+		{
+			name: "Non-ASCII TaskShort (should split by characters, not bytes)",
+			input: &RawTask{
+				Line:      "- (üä 9:00 - 10:00) Umlaut",
+				TaskShort: "üä",
+				StartTime: "9:00",
+				EndTime:   "10:00",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "Ü",
+				SubType:      "ä",
+				StartTime:    "09:00",
+				EndTime:      "10:00",
+				StartMins:    540,
+				EndMins:      600,
+				DurationMins: 60,
+			},
+			ok: true,
+		},
+		{
+			name: "Valid two-letter TaskShort (should keep second letter as sub type)",
+			input: &RawTask{
+				Line:      "- (Mr 11:00 - 12:00) Retro",
+				TaskShort: "Mr",
+				StartTime: "11:00",
+				EndTime:   "12:00",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "M",
+				SubType:      "r",
+				StartTime:    "11:00",
+				EndTime:      "12:00",
+				StartMins:    660,
+				EndMins:      720,
+				DurationMins: 60,
+			},
+			ok: true,
+		},
+		{
+			name: "Valid with tickets and tags in description",
+			input: &RawTask{
+				Line:        "- (A 9:00 - 10:00) Fix JIRA-123, see JIRA-123 and OPS-7 #backend #urgent #backend #42",
+				TaskShort:   "A",
+				StartTime:   "9:00",
+				EndTime:     "10:00",
+				Description: "Fix JIRA-123, see JIRA-123 and OPS-7 #backend #urgent #backend #42",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "A",
+				StartTime:    "09:00",
+				EndTime:      "10:00",
+				StartMins:    540,
+				EndMins:      600,
+				DurationMins: 60,
+				Description:  "Fix JIRA-123, see JIRA-123 and OPS-7 #backend #urgent #backend #42",
+				Tickets:      []string{"JIRA-123", "OPS-7"},
+				Tags:         []string{"backend", "urgent"},
+			},
+			ok: true,
+		},
+		{
+			name: "Alternative time notations (should be normalized)",
+			input: &RawTask{
+				Line:      "- (A 9.30 - 2:15pm) Task description",
+				TaskShort: "A",
+				StartTime: "9.30",
+				EndTime:   "2:15pm",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "A",
+				StartTime:    "09:30",
+				EndTime:      "14:15",
+				StartMins:    570,
+				EndMins:      855,
+				DurationMins: 285,
+			},
+			ok: true,
+		},
+		{
+			name: "Duration only task",
+			input: &RawTask{
+				Line:      "- (A 1h30) spec review",
+				TaskShort: "A",
+				Duration:  "1h30",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "A",
+				DurationMins: 90,
+				DurationOnly: true,
+			},
+			ok: true,
+		},
+		{
+			name: "Duration only task with invalid duration",
+			input: &RawTask{
+				Line:      "- (A 1x30) spec review",
+				TaskShort: "A",
+				Duration:  "1x30",
+			},
+			expected: nil,
+			ok:       false,
+		},
+		{
+			name: "Running task without end time",
+			input: &RawTask{
+				Line:      "- (A 14:05 - ) working on release",
+				TaskShort: "A",
+				StartTime: "14:05",
+				EndTime:   "",
+			},
+			expected: &ParsedTask{
+				TaskShort: "A",
+				StartTime: "14:05",
+				StartMins: 845,
+				Running:   true,
+			},
+			ok: true,
+		},
 		{
 			name: "Valid with leading zeros",
 			input: &RawTask{
@@ -237,23 +780,58 @@ func TestConvertRawToParsed(t *testing.T) {
 				TaskShort:    "V",
 				StartTime:    "01:23",
 				EndTime:      "04:56",
+				StartMins:    83,
+				EndMins:      296,
 				DurationMins: 213,
 			},
 			ok: true,
 		},
 		{
-			name: "End time before start time (should return positive duration)",
+			name: "End time before start time (should be rejected as reversed range)",
 			input: &RawTask{
 				Line:      "- (V 14:56 - 11:23) Task description",
 				TaskShort: "V",
 				StartTime: "14:56",
 				EndTime:   "11:23",
 			},
+			expected: nil,
+			ok:       false,
+		},
+		{
+			name: "End time after midnight (should cross midnight)",
+			input: &RawTask{
+				Line:      "- (S 23:30 - 00:15) Task description",
+				TaskShort: "S",
+				StartTime: "23:30",
+				EndTime:   "00:15",
+			},
 			expected: &ParsedTask{
-				TaskShort:    "V",
+				TaskShort:    "S",
+				StartTime:    "23:30",
+				EndTime:      "00:15",
+				StartMins:    1410,
+				EndMins:      15,
+				DurationMins: 45,
+				EndsNextDay:  true,
+			},
+			ok: true,
+		},
+		{
+			name: "End time with next day marker (should cross midnight)",
+			input: &RawTask{
+				Line:      "- (S 14:56 - 11:23+1) Task description",
+				TaskShort: "S",
+				StartTime: "14:56",
+				EndTime:   "11:23+1",
+			},
+			expected: &ParsedTask{
+				TaskShort:    "S",
 				StartTime:    "14:56",
 				EndTime:      "11:23",
-				DurationMins: 213,
+				StartMins:    896,
+				EndMins:      683,
+				DurationMins: 1227,
+				EndsNextDay:  true,
 			},
 			ok: true,
 		},
@@ -338,7 +916,8 @@ func TestConvertRawToParsed(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, ok := ConvertRawToParsed(tt.input)
+			result, err := ConvertRawToParsed(tt.input)
+			ok := err == nil
 			if ok != tt.ok {
 				t.Errorf("ConvertRawToParsed(%+v) ok = %v; want %v", tt.input, ok, tt.ok)
 			}
@@ -351,3 +930,137 @@ func TestConvertRawToParsed(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertRawToParsedErrorColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  *RawTask
+		column int
+	}{
+		{
+			name: "Invalid StartTime",
+			input: &RawTask{
+				Line:      "- (A xx:23 - 4:56) Task description",
+				TaskShort: "A",
+				StartTime: "xx:23",
+				EndTime:   "4:56",
+			},
+			column: 6,
+		},
+		{
+			name: "Invalid EndTime",
+			input: &RawTask{
+				Line:      "- (A 9:00 - 10;00) Task description",
+				TaskShort: "A",
+				StartTime: "9:00",
+				EndTime:   "10;00",
+			},
+			column: 13,
+		},
+		{
+			name: "Reversed range",
+			input: &RawTask{
+				Line:      "- (A 10:00 - 9:00) Task description",
+				TaskShort: "A",
+				StartTime: "10:00",
+				EndTime:   "9:00",
+			},
+			column: 14,
+		},
+		{
+			name: "Single minute digit",
+			input: &RawTask{
+				Line:      "- (A 9.5 - 10.5) Task description",
+				TaskShort: "A",
+				StartTime: "9.5",
+				EndTime:   "10.5",
+			},
+			column: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertRawToParsed(tt.input)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Column != tt.column {
+				t.Errorf("ConvertRawToParsed(%+v) err = %v; want parse error at column %d", tt.input, err, tt.column)
+			}
+		})
+	}
+}
+
+func TestParseTaskLineErrorColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		column int
+	}{
+		{
+			name:   "Second range",
+			input:  "- (A 9:00 - 10:00 - 11:00) Task description",
+			column: 19,
+		},
+		{
+			name:   "Text after the end time",
+			input:  "- (A 9:00-10:00 lunch) Task description",
+			column: 17,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTaskLine(tt.input)
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Column != tt.column {
+				t.Errorf("ParseTaskLine(%q) err = %v; want parse error at column %d", tt.input, err, tt.column)
+			}
+		})
+	}
+}
+
+func TestParseTimeStringToMins(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		ok       bool
+	}{
+		{name: "Colon", input: "9:30", expected: 570, ok: true},
+		{name: "Colon with leading zero", input: "09:30", expected: 570, ok: true},
+		{name: "Dot", input: "9.30", expected: 570, ok: true},
+		{name: "Compact four digits", input: "0930", expected: 570, ok: true},
+		{name: "Hour marker", input: "9h30", expected: 570, ok: true},
+		{name: "Hour marker without minutes", input: "9h", expected: 540, ok: true},
+		{name: "Afternoon", input: "2:15pm", expected: 855, ok: true},
+		{name: "Afternoon without minutes", input: "2PM", expected: 840, ok: true},
+		{name: "Noon", input: "12pm", expected: 720, ok: true},
+		{name: "Midnight", input: "12:30am", expected: 30, ok: true},
+		{name: "Last minute of day", input: "23:59", expected: 1439, ok: true},
+		{name: "Minutes out of range", input: "9:60", ok: false},
+		{name: "Hours out of range", input: "24:00", ok: false},
+		{name: "Compact hours out of range", input: "2500", ok: false},
+		{name: "12-hour clock out of range", input: "13pm", ok: false},
+		{name: "Three digits", input: "930", ok: false},
+		{name: "Negative", input: "-1:23", ok: false},
+		{name: "Missing minutes", input: "9:", ok: false},
+		{name: "Single minute digit", input: "9.5", ok: false},
+		{name: "Single minute digit after hour marker", input: "9h5", ok: false},
+		{name: "Three minute digits", input: "9:300", ok: false},
+		{name: "Empty string", input: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parseTimeStringToMins(tt.input)
+			if ok != tt.ok {
+				t.Errorf("parseTimeStringToMins(%q) ok = %v; want %v", tt.input, ok, tt.ok)
+			}
+			if ok && result != tt.expected {
+				t.Errorf("parseTimeStringToMins(%q) = %d; want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+)
+
+// Kind of a line within a timebook document
+type LineKind string
+
+const (
+	// A line of the front matter block, including its delimiters
+	LineKindFrontMatter LineKind = "frontMatter"
+	// A markdown heading, possibly starting a day
+	LineKindHeading LineKind = "heading"
+	// A task entry like "- (A 9:00 - 10:00) Task description"
+	LineKindEntry LineKind = "entry"
+	// An expection like "> - Planned Work A: 160h"
+	LineKindExpection LineKind = "expection"
+	// A list item without task entry, used as note to its parent entry
+	LineKindNote LineKind = "note"
+	// Any other line, including empty ones
+	LineKindText LineKind = "text"
+)
+
+// A single line of a timebook document with its exact position
+type DocumentLine struct {
+	Kind LineKind
+	// 1-based line number within the document
+	Number int
+	// Byte offset of the first character of the line within the document
+	Offset int
+	// The line as written, without line ending
+	Text string
+	// The line ending as written: "\n", "\r\n" or empty for the last line
+	Ending string
+	// Width of the indentation, tabs count as 4 spaces
+	IndentWidth int
+
+	// Level and text of headings
+	HeadingLevel int
+	HeadingText  string
+	// Day of date headings and of entries, zero if unknown
+	Date time.Time
+	// The parsed task of entries, nil if it could not be parsed
+	Task *ParsedTask
+	// The parsed expection of expection lines, nil if it could not be parsed
+	Expection *ParsedExpection
+	// Text of notes
+	Note string
+	// Error of entries and expections that could not be parsed
+	Err error
+}
+
+// A day of a timebook document, started by a date heading
+type Day struct {
+	// The day of the heading, zero for entries before the first date heading
+	Date time.Time
+	// 0-based index of the heading line, -1 for entries before the first date heading
+	HeadingIndex int
+	// 0-based indexes of the entry lines of the day
+	EntryIndexes []int
+}
+
+// A markdown section, from its heading to the next heading of the same or a higher level
+type Section struct {
+	// Level and text of the heading
+	Level   int
+	Heading string
+	// 0-based index of the heading line and of the first line after the section
+	HeadingIndex int
+	EndIndex     int
+	// Index of the enclosing section, -1 for top-level sections
+	Parent int
+}
+
+// Days and sections of a timebook document, collected line by line
+// Only indexes of lines are kept, not the lines themselves.
+type Outline struct {
+	// All days in order of their headings
+	// Days without entries are included, entries before the first date heading
+	// form a day of their own.
+	Days []Day
+	// All sections in order of their headings, nested sections follow their parent
+	Sections []Section
+
+	// Indexes of the sections enclosing the current line, innermost last
+	open []int
+}
+
+// A timebook parsed into lines of known kinds, that can be written back as is
+type Document struct {
+	// All lines in order of appearance
+	Lines []DocumentLine
+	// Number of lines of the front matter block, zero if there is none
+	FrontMatterLines int
+	// Days and sections of the lines
+	Days     []Day
+	Sections []Section
+}
+
+// Parse timebook content into a document
+// Writing the document with Bytes results in the identical content.
+func ParseDocument(content []byte) *Document {
+	// reading from memory cannot fail
+	document, _ := ReadDocument(bytes.NewReader(content))
+	return document
+}
+
+// Read and parse a timebook into a document
+// Returns the lines read so far and an error if reading failed.
+func ReadDocument(reader io.Reader) (*Document, error) {
+	document := &Document{
+		Lines: make([]DocumentLine, 0),
+	}
+
+	outline := NewOutline()
+	scanner := NewLineScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Line()
+		document.Lines = append(document.Lines, line)
+		outline.Add(line)
+
+		if line.Kind == LineKindFrontMatter {
+			document.FrontMatterLines++
+		}
+	}
+
+	outline.Close(len(document.Lines))
+	document.Days = outline.Days
+	document.Sections = outline.Sections
+
+	return document, scanner.Err()
+}
+
+// Write the document back to content
+func (d *Document) Bytes() []byte {
+	var builder strings.Builder
+	for _, line := range d.Lines {
+		builder.WriteString(line.Text)
+		builder.WriteString(line.Ending)
+	}
+
+	return []byte(builder.String())
+}
+
+// Get all lines of the given kind in order of appearance
+func (d *Document) LinesOfKind(kind LineKind) []DocumentLine {
+	lines := make([]DocumentLine, 0)
+	for _, line := range d.Lines {
+		if line.Kind == kind {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// Create an empty outline, to add the lines of a document to
+func NewOutline() *Outline {
+	return &Outline{
+		Days:     make([]Day, 0),
+		Sections: make([]Section, 0),
+	}
+}
+
+// Add the next line of the document to its day and section
+func (o *Outline) Add(line DocumentLine) {
+	index := line.Number - 1
+
+	switch line.Kind {
+	case LineKindHeading:
+		o.addSection(line, index)
+		if !line.Date.IsZero() {
+			o.Days = append(o.Days, Day{Date: line.Date, HeadingIndex: index, EntryIndexes: make([]int, 0)})
+		}
+
+	case LineKindEntry:
+		if len(o.Days) == 0 {
+			o.Days = append(o.Days, Day{HeadingIndex: -1, EntryIndexes: make([]int, 0)})
+		}
+		day := &o.Days[len(o.Days)-1]
+		day.EntryIndexes = append(day.EntryIndexes, index)
+	}
+}
+
+// Start a section at a heading, closing all open sections of the same or a deeper level
+func (o *Outline) addSection(heading DocumentLine, index int) {
+	for len(o.open) > 0 && o.Sections[o.open[len(o.open)-1]].Level >= heading.HeadingLevel {
+		o.Sections[o.open[len(o.open)-1]].EndIndex = index
+		o.open = o.open[:len(o.open)-1]
+	}
+
+	parent := -1
+	if len(o.open) > 0 {
+		parent = o.open[len(o.open)-1]
+	}
+
+	o.open = append(o.open, len(o.Sections))
+	o.Sections = append(o.Sections, Section{
+		Level:        heading.HeadingLevel,
+		Heading:      heading.HeadingText,
+		HeadingIndex: index,
+		Parent:       parent,
+	})
+}
+
+// Get the index of the innermost section enclosing the last added line
+// Returns -1 for lines before the first heading.
+func (o *Outline) CurrentSection() int {
+	if len(o.open) == 0 {
+		return -1
+	}
+
+	return o.open[len(o.open)-1]
+}
+
+// Close all open sections at the end of the document with the given number of lines
+func (o *Outline) Close(lineCount int) {
+	for _, index := range o.open {
+		o.Sections[index].EndIndex = lineCount
+	}
+	o.open = nil
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty content", input: ""},
+		{name: "Trailing newline", input: "## 2025-10-09\n- (A 9:00 - 10:00) work\n"},
+		{name: "No trailing newline", input: "## 2025-10-09\n- (A 9:00 - 10:00) work"},
+		{name: "Windows line endings", input: "## 2025-10-09\r\n- (A 9:00 - 10:00) work\r\n"},
+		{name: "Mixed line endings", input: "a\r\nb\nc\r"},
+		{name: "Empty lines only", input: "\n\n\n"},
+		{name: "Front matter and multi-byte characters", input: "---\nperson: Jörg\n---\n- (A 9:00 - …) läuft\n"},
+		{name: "Invalid entries", input: "- (A 9:00\n> - Task A: lots\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := string(ParseDocument([]byte(tt.input)).Bytes())
+			if result != tt.input {
+				t.Errorf("ParseDocument(%q).Bytes() = %q; want %q", tt.input, result, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseDocumentLines(t *testing.T) {
+	input := "---\nperson: Jane Doe\n---\n> - Planned A: 20h\n## 2025-10-09\r\n- (A 9:00 - 10:00) work\n  - note\n\nText\n"
+	document := ParseDocument([]byte(input))
+
+	expectedKinds := []LineKind{
+		LineKindFrontMatter,
+		LineKindFrontMatter,
+		LineKindFrontMatter,
+		LineKindExpection,
+		LineKindHeading,
+		LineKindEntry,
+		LineKindNote,
+		LineKindText,
+		LineKindText,
+	}
+	kinds := make([]LineKind, 0)
+	for _, line := range document.Lines {
+		kinds = append(kinds, line.Kind)
+	}
+	if !slices.Equal(kinds, expectedKinds) {
+		t.Fatalf("ParseDocument(%q) kinds = %v; want %v", input, kinds, expectedKinds)
+	}
+
+	if document.FrontMatterLines != 3 {
+		t.Errorf("FrontMatterLines = %d; want 3", document.FrontMatterLines)
+	}
+
+	// offsets point to the start of each line within the input
+	for _, line := range document.Lines {
+		if input[line.Offset:line.Offset+len(line.Text)] != line.Text {
+			t.Errorf("line %d at offset %d = %q; want %q", line.Number, line.Offset, input[line.Offset:line.Offset+len(line.Text)], line.Text)
+		}
+	}
+
+	heading := document.Lines[4]
+	if heading.Ending != "\r\n" || heading.HeadingLevel != 2 || heading.HeadingText != "2025-10-09" {
+		t.Errorf("heading = %+v; want level 2, text \"2025-10-09\" and ending \"\\r\\n\"", heading)
+	}
+
+	entry := document.Lines[5]
+	expectedDate := time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC)
+	if entry.Err != nil || entry.Task == nil || entry.Task.DurationMins != 60 || !entry.Task.Date.Equal(expectedDate) {
+		t.Errorf("entry = %+v; want a task of 60 minutes on %v", entry, expectedDate)
+	}
+
+	if expection := document.Lines[3].Expection; expection == nil || expection.DurationMins != 20*60 {
+		t.Errorf("expection = %+v; want 1200 minutes", expection)
+	}
+
+	if note := document.Lines[6].Note; note != "note" {
+		t.Errorf("note = %q; want %q", note, "note")
+	}
+}
+
+func TestParseDocumentDays(t *testing.T) {
+	input := "- (A 8:00 - 9:00) undated\n## 2025-10-09\n- (A 9:00 - 10:00)\n- (A 25:00 - 26:00)\n#release shipped 2025-10-10\n- (A 11:00 - 12:00)\n## 2025-10-11\n## 2025-10-12\n- (M 30m)\n"
+	document := ParseDocument([]byte(input))
+
+	expected := []Day{
+		{HeadingIndex: -1, EntryIndexes: []int{0}},
+		{Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), HeadingIndex: 1, EntryIndexes: []int{2, 3, 5}},
+		{Date: time.Date(2025, 10, 11, 0, 0, 0, 0, time.UTC), HeadingIndex: 6, EntryIndexes: []int{}},
+		{Date: time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC), HeadingIndex: 7, EntryIndexes: []int{8}},
+	}
+
+	if len(document.Days) != len(expected) {
+		t.Fatalf("ParseDocument(%q) days = %+v; want %+v", input, document.Days, expected)
+	}
+	for i, day := range document.Days {
+		if !day.Date.Equal(expected[i].Date) || day.HeadingIndex != expected[i].HeadingIndex || !slices.Equal(day.EntryIndexes, expected[i].EntryIndexes) {
+			t.Errorf("day %d = %+v; want %+v", i, day, expected[i])
+		}
+	}
+
+	// text starting with "#" is no heading and keeps the day
+	if tagged := document.Lines[4]; tagged.Kind != LineKindText {
+		t.Errorf("line %q kind = %v; want %v", tagged.Text, tagged.Kind, LineKindText)
+	}
+
+	// invalid entries keep their error
+	if invalid := document.Lines[3]; invalid.Task != nil || invalid.Err == nil {
+		t.Errorf("invalid entry = %+v; want an error", invalid)
+	}
+}
+
+func TestParseDocumentSections(t *testing.T) {
+	input := "---\ntitle: x\n---\n# October\n## Week 41\n### 2025-10-09\n- (A 9:00 - 10:00)\n## Week 42\n# Notes\n"
+	document := ParseDocument([]byte(input))
+
+	expected := []Section{
+		{Level: 1, Heading: "October", HeadingIndex: 3, EndIndex: 8, Parent: -1},
+		{Level: 2, Heading: "Week 41", HeadingIndex: 4, EndIndex: 7, Parent: 0},
+		{Level: 3, Heading: "2025-10-09", HeadingIndex: 5, EndIndex: 7, Parent: 1},
+		{Level: 2, Heading: "Week 42", HeadingIndex: 7, EndIndex: 8, Parent: 0},
+		{Level: 1, Heading: "Notes", HeadingIndex: 8, EndIndex: 9, Parent: -1},
+	}
+	if !slices.Equal(document.Sections, expected) {
+		t.Errorf("ParseDocument(%q) sections = %+v; want %+v", input, document.Sections, expected)
+	}
+
+	if headings := document.LinesOfKind(LineKindHeading); len(headings) != len(expected) {
+		t.Errorf("headings = %d; want %d", len(headings), len(expected))
+	}
+}
+
+// Reading time per line stays the same for growing archives
+func BenchmarkReadDocument(b *testing.B) {
+	for _, lineCount := range []int{1_000, 10_000, 100_000, 300_000} {
+		archive := benchmarkArchive(lineCount)
+
+		b.Run(fmt.Sprintf("lines=%d", lineCount), func(b *testing.B) {
+			b.SetBytes(int64(len(archive)))
+			for i := 0; i < b.N; i++ {
+				if _, err := ReadDocument(strings.NewReader(archive)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*lineCount), "ns/line")
+		})
+	}
+}
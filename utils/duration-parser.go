@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse a duration to minutes, rounded to whole minutes
+// A bare number is read as hours, decimals may use a dot or a comma.
+// Example duration: "178h", "178"
+// Example duration: "7.5h", "7,5h"
+// Example duration: "160h 30m", "1h30", "90m", "90min"
+// Example duration: "1:30"
+func ParseDurationToMins(duration string) (int, bool) {
+	duration = strings.ToLower(strings.TrimSpace(duration))
+	if len(duration) == 0 {
+		return 0, false
+	}
+
+	// Clock notation "H:MM"
+	if hoursPart, minutesPart, found := strings.Cut(duration, ":"); found {
+		hours, err1 := strconv.Atoi(hoursPart)
+		minutes, err2 := strconv.Atoi(minutesPart)
+		if err1 != nil || err2 != nil || hours < 0 || minutes < 0 || minutes >= 60 {
+			return 0, false
+		}
+
+		return hours*60 + minutes, true
+	}
+
+	totalMins := 0.0
+	previousUnit := ""
+	rest := duration
+	for len(rest) > 0 {
+		// Each component is a number followed by an optional unit
+		numberLength := strings.IndexFunc(rest, func(r rune) bool {
+			return !unicode.IsDigit(r) && r != '.' && r != ','
+		})
+		if numberLength == -1 {
+			numberLength = len(rest)
+		}
+		if numberLength == 0 {
+			return 0, false
+		}
+
+		value, err := strconv.ParseFloat(strings.Replace(rest[:numberLength], ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		rest = strings.TrimLeft(rest[numberLength:], " ")
+
+		unitLength := strings.IndexFunc(rest, func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		if unitLength == -1 {
+			unitLength = len(rest)
+		}
+		unit := rest[:unitLength]
+		rest = strings.TrimLeft(rest[unitLength:], " ")
+
+		switch {
+		case unit == "h":
+			totalMins += value * 60
+		case unit == "m" || unit == "min":
+			totalMins += value
+		case unit == "" && previousUnit == "h":
+			// Minutes may follow hours without unit (e.g. "1h30")
+			totalMins += value
+		case unit == "" && previousUnit == "" && len(rest) == 0:
+			// A bare number is read as hours
+			totalMins += value * 60
+		default:
+			return 0, false
+		}
+		previousUnit = unit
+	}
+
+	return int(math.Round(totalMins)), true
+}
@@ -0,0 +1,44 @@
+package utils
+
+import "testing"
+
+func TestParseDurationToMins(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		ok       bool
+	}{
+		{name: "Whole hours", input: "178h", expected: 178 * 60, ok: true},
+		{name: "Bare number as hours", input: "178", expected: 178 * 60, ok: true},
+		{name: "Decimal hours with dot", input: "7.5h", expected: 450, ok: true},
+		{name: "Decimal hours with comma", input: "7,5h", expected: 450, ok: true},
+		{name: "Hours and minutes", input: "160h 30m", expected: 160*60 + 30, ok: true},
+		{name: "Hours and minutes without space", input: "160h30m", expected: 160*60 + 30, ok: true},
+		{name: "Hours and minutes without unit", input: "1h30", expected: 90, ok: true},
+		{name: "Minutes only", input: "90m", expected: 90, ok: true},
+		{name: "Minutes with long unit", input: "45min", expected: 45, ok: true},
+		{name: "Clock notation", input: "1:30", expected: 90, ok: true},
+		{name: "Upper case and spaces", input: "  2H ", expected: 120, ok: true},
+		{name: "Rounded to whole minutes", input: "0.33h", expected: 20, ok: true},
+		{name: "Empty string", input: "", ok: false},
+		{name: "Negative hours", input: "-1h", ok: false},
+		{name: "Unknown unit", input: "3d", ok: false},
+		{name: "Two separators", input: "1.5.2h", ok: false},
+		{name: "Letters only", input: "h", ok: false},
+		{name: "Clock notation with too many minutes", input: "1:75", ok: false},
+		{name: "Minutes followed by bare number", input: "30m 10", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseDurationToMins(tt.input)
+			if ok != tt.ok {
+				t.Errorf("ParseDurationToMins(%q) ok = %v; want %v", tt.input, ok, tt.ok)
+			}
+			if ok && result != tt.expected {
+				t.Errorf("ParseDurationToMins(%q) = %d; want %d", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -1,52 +1,81 @@
 package utils
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// File extension of timebook files
+const timebookFileExtension = ".md"
+
 // Load file to string array
 // Returns an array of strings of each line in the file, separated by newline
 // or an error if the file could not be read.
-// Empty lines are ignored.
+// Lines are read through a LineScanner, which also keeps their positions.
 func LoadFileToStringArray(filePath string) ([]string, error) {
-	// Read file
-	fileContent, err := os.ReadFile(filePath)
+	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	defer file.Close()
 
-	lines := RetrieveLinesFromContent(fileContent)
+	lines, err := readLines(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
 
 	return lines, nil
 }
 
+// List all timebook files (*.md) of a directory, ordered by name
+// Subdirectories and hidden files are skipped.
+func ListTimebookFiles(dirPath string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	filePaths := make([]string, 0)
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || strings.HasPrefix(name, ".") || !strings.EqualFold(filepath.Ext(name), timebookFileExtension) {
+			continue
+		}
+
+		filePaths = append(filePaths, filepath.Join(dirPath, name))
+	}
+	sort.Strings(filePaths)
+
+	return filePaths, nil
+}
+
 // Split file content into lines
 func RetrieveLinesFromContent(fileContent []byte) []string {
+	// reading from memory cannot fail
+	lines, _ := readLines(bytes.NewReader(fileContent))
+	return lines
+}
+
+// Read all lines of a reader, without line endings and carriage returns
+func readLines(reader io.Reader) ([]string, error) {
 	lines := make([]string, 0)
 
-	currentLine := ""
-	for _, char := range fileContent {
-		switch char {
-		case '\n':
-			// Newline indicates end of line
-			lines = append(lines, currentLine)
-			currentLine = ""
-			continue
+	scanner := NewLineScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Line()
+		text := strings.ReplaceAll(line.Text, "\r", "")
 
-		case '\r':
-			// Ignore carriage return
+		// a last line of carriage returns only is no line
+		if text == "" && line.Ending == "" {
 			continue
 		}
-
-		// There are no "continues", so let's add character to current line
-		currentLine += string(char)
+		lines = append(lines, text)
 	}
 
-	// We've reached EOF, so add any remaining current line to lines array
-	if len(currentLine) > 0 {
-		lines = append(lines, currentLine)
-	}
-
-	return lines
+	return lines, scanner.Err()
 }
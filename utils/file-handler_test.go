@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -46,6 +48,11 @@ func TestRetrieveLinesFromContent(t *testing.T) {
 			input:    []byte("line1\n\nline3\n"),
 			expected: []string{"line1", "", "line3"},
 		},
+		{
+			name:     "Multi-byte characters",
+			input:    []byte("- (A 14:05 - …) Änderung\nline2"),
+			expected: []string{"- (A 14:05 - …) Änderung", "line2"},
+		},
 		{
 			name:     "Carriage return at end",
 			input:    []byte("line1\r"),
@@ -62,3 +69,33 @@ func TestRetrieveLinesFromContent(t *testing.T) {
 		})
 	}
 }
+
+func TestListTimebookFiles(t *testing.T) {
+	dirPath := t.TempDir()
+	for _, name := range []string{"2025-10.md", "2025-09.md", "notes.txt", ".draft.md", "2025-11.MD"} {
+		if err := os.WriteFile(filepath.Join(dirPath, name), []byte("## 2025-10-09\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dirPath, "archive.md"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	filePaths, err := ListTimebookFiles(dirPath)
+	if err != nil {
+		t.Fatalf("ListTimebookFiles() error = %v; want nil", err)
+	}
+
+	expected := []string{
+		filepath.Join(dirPath, "2025-09.md"),
+		filepath.Join(dirPath, "2025-10.md"),
+		filepath.Join(dirPath, "2025-11.MD"),
+	}
+	if !reflect.DeepEqual(filePaths, expected) {
+		t.Errorf("ListTimebookFiles() = %v; want %v", filePaths, expected)
+	}
+
+	if _, err := ListTimebookFiles(filepath.Join(dirPath, "missing")); err == nil {
+		t.Error("ListTimebookFiles() of a missing directory error = nil; want an error")
+	}
+}
@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rewrite a timebook file into its canonical form
+// Returns whether the content of the file changed.
+func FormatFile(filePath string) (bool, error) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	formattedContent := FormatContent(fileContent)
+	if bytes.Equal(formattedContent, fileContent) {
+		return false, nil
+	}
+
+	if err := os.WriteFile(filePath, formattedContent, fileInfo.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return true, nil
+}
+
+// Rewrite timebook content into its canonical form
+// Task entries get normalized spacing, zero-padded times and upper-case task
+// shorts and are sorted by start within each list. Expectation blocks are
+// aligned. All other content, including line endings, stays as is.
+func FormatContent(content []byte) []byte {
+	document := ParseDocument(content)
+
+	// the front matter is no timebook content
+	// Line endings stay at their position, as lines may be reordered.
+	lines := document.Lines[document.FrontMatterLines:]
+	for index, text := range FormatLines(lines) {
+		lines[index].Text = text
+	}
+
+	return document.Bytes()
+}
+
+// Rewrite lines of a timebook document into their canonical form
+// Returns the text of the lines, which are only reordered within lists.
+func FormatLines(lines []DocumentLine) []string {
+	formattedLines := make([]DocumentLine, len(lines))
+	for index, line := range lines {
+		if line.Kind == LineKindEntry && line.Task != nil {
+			line.Text = FormatTaskLine(line.Text)
+		}
+		formattedLines[index] = line
+	}
+
+	alignExpectionBlocks(formattedLines)
+
+	texts := make([]string, 0, len(lines))
+	for _, line := range sortTaskEntries(formattedLines) {
+		texts = append(texts, line.Text)
+	}
+
+	return texts
+}
+
+// Rewrite a single task line into its canonical form
+// Lines that are no task lines or cannot be parsed are returned as is.
+// Example line: "  - (mm 9:30 -  10:00)standup" results in "  - (Mm 09:30 - 10:00) standup"
+func FormatTaskLine(line string) string {
+	trimmedLine, ok := FilterAndTrimLine(line)
+	if !ok {
+		return line
+	}
+	indent := line[:strings.Index(line, trimmedLine)]
+
+	raw, err := ParseTaskLine(trimmedLine)
+	if err != nil {
+		return line
+	}
+
+	parsed, err := ConvertRawToParsed(raw)
+	if err != nil {
+		return line
+	}
+
+	// the task short must survive the rewrite, apart from its case
+	taskShort := parsed.TaskShort + parsed.SubType
+	if !utf8.ValidString(raw.TaskShort) || !strings.EqualFold(taskShort, raw.TaskShort) {
+		return line
+	}
+
+	var builder strings.Builder
+	builder.WriteString(indent)
+	builder.WriteString("- (")
+	builder.WriteString(taskShort)
+	builder.WriteString(" ")
+
+	switch {
+	case parsed.DurationOnly:
+		builder.WriteString(raw.Duration)
+	case parsed.Running:
+		builder.WriteString(parsed.StartTime + " - " + runningEndMarkers[0])
+	default:
+		builder.WriteString(parsed.StartTime + " - " + parsed.EndTime)
+		if strings.HasSuffix(raw.EndTime, nextDayMarker) {
+			builder.WriteString(nextDayMarker)
+		}
+	}
+
+	builder.WriteString(")")
+	if parsed.Description != "" {
+		builder.WriteString(" " + parsed.Description)
+	}
+
+	return builder.String()
+}
+
+// Align the durations of consecutive expection lines
+// Example lines: "> - Planned Work A: 160h" and "> - Meetings M:     20h"
+func alignExpectionBlocks(lines []DocumentLine) {
+	for start := 0; start < len(lines); {
+		// collect the labels and durations of the block starting here
+		labels := make([]string, 0)
+		durations := make([]string, 0)
+		for _, line := range lines[start:] {
+			if line.Kind != LineKindExpection || line.Expection == nil {
+				break
+			}
+			label, duration := splitExpectionLine(line)
+			labels = append(labels, label)
+			durations = append(durations, duration)
+		}
+		if len(labels) == 0 {
+			start++
+			continue
+		}
+
+		width := 0
+		for _, label := range labels {
+			width = max(width, utf8.RuneCountInString(label))
+		}
+		for i, label := range labels {
+			padding := strings.Repeat(" ", width-utf8.RuneCountInString(label)+1)
+			lines[start+i].Text = label + padding + durations[i]
+		}
+
+		start += len(labels)
+	}
+}
+
+// Split an expection line into its canonical label up to the colon and its duration
+func splitExpectionLine(line DocumentLine) (string, string) {
+	colonIndex := strings.Index(line.Text, ":")
+	_, taskPartSize := utf8.DecodeLastRuneInString(line.Text[:colonIndex])
+	label := strings.TrimSpace(line.Text[4 : colonIndex-taskPartSize])
+	if label != "" {
+		label += " "
+	}
+
+	return "> - " + label + line.Expection.TaskShort + ":", strings.TrimSpace(line.Text[colonIndex+1:])
+}
+
+// A task entry together with the nested lines following it
+type taskEntryLines struct {
+	lines     []DocumentLine
+	startMins int
+}
+
+// Sort the task entries of each list by their start
+// Nested lines move along with their entry. Entries without start keep their
+// position after the preceding entry.
+func sortTaskEntries(lines []DocumentLine) []DocumentLine {
+	sortedLines := make([]DocumentLine, 0, len(lines))
+
+	for index := 0; index < len(lines); {
+		if lines[index].Kind != LineKindEntry {
+			sortedLines = append(sortedLines, lines[index])
+			index++
+			continue
+		}
+
+		// collect the entries of the list starting here
+		indentWidth := lines[index].IndentWidth
+		entries := make([]taskEntryLines, 0)
+		for index < len(lines) {
+			line := lines[index]
+			if strings.TrimSpace(line.Text) == "" {
+				break
+			}
+
+			// nested lines belong to the current entry
+			if line.IndentWidth > indentWidth && len(entries) > 0 {
+				entries[len(entries)-1].lines = append(entries[len(entries)-1].lines, line)
+				index++
+				continue
+			}
+
+			if line.Kind != LineKindEntry || line.IndentWidth != indentWidth {
+				break
+			}
+
+			startMins, ok := taskStartMins(line)
+			if !ok && len(entries) > 0 {
+				startMins = entries[len(entries)-1].startMins
+			}
+			entries = append(entries, taskEntryLines{lines: []DocumentLine{line}, startMins: startMins})
+			index++
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].startMins < entries[j].startMins
+		})
+		for _, entry := range entries {
+			sortedLines = append(sortedLines, entry.lines...)
+		}
+	}
+
+	return sortedLines
+}
+
+// Get the start of a task entry in minutes since midnight
+// Returns false for tasks without start and entries that could not be parsed.
+func taskStartMins(line DocumentLine) (int, bool) {
+	if line.Task == nil || line.Task.DurationOnly {
+		return 0, false
+	}
+
+	return line.Task.StartMins, true
+}
@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFormatTaskLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Canonical line",
+			input:    "- (A 09:00 - 10:00) Task description",
+			expected: "- (A 09:00 - 10:00) Task description",
+		},
+		{
+			name:     "Spacing and padding",
+			input:    "- (a  9:00 -   10:00)Task description  ",
+			expected: "- (A 09:00 - 10:00) Task description",
+		},
+		{
+			name:     "Sub type and indentation",
+			input:    "  - (mM 9.30 - 9h45) standup",
+			expected: "  - (Mm 09:30 - 09:45) standup",
+		},
+		{
+			name:     "Explicit next day",
+			input:    "- (S 22:00 - 1:00+1) incident",
+			expected: "- (S 22:00 - 01:00+1) incident",
+		},
+		{
+			name:     "Running task",
+			input:    "- (A 14:05 - ) Running task",
+			expected: "- (A 14:05 - …) Running task",
+		},
+		{
+			name:     "Duration only",
+			input:    "- (s  45m )",
+			expected: "- (S 45m)",
+		},
+		{
+			name:     "Range without spaces",
+			input:    "- (a 9:00-10:00)Task description",
+			expected: "- (A 09:00 - 10:00) Task description",
+		},
+		{
+			name:     "Range without space after the dash",
+			input:    "- (a 9:00 -10:00)foo",
+			expected: "- (A 09:00 - 10:00) foo",
+		},
+		{
+			name:     "Running task without spaces",
+			input:    "- (A 14:05-) Running task",
+			expected: "- (A 14:05 - …) Running task",
+		},
+		{
+			name:     "Non-ASCII task short",
+			input:    "- (ü 9:00 - 10:00)",
+			expected: "- (Ü 09:00 - 10:00)",
+		},
+		{
+			name:     "Non-ASCII sub type",
+			input:    "- (mÜ 9:00 - 10:00) Übung",
+			expected: "- (Mü 09:00 - 10:00) Übung",
+		},
+		{
+			name:     "Longer task short stays as is",
+			input:    "- (Abc 9:00 - 10:00) text",
+			expected: "- (Abc 9:00 - 10:00) text",
+		},
+		{
+			name:     "Invalid UTF-8 task short stays as is",
+			input:    "- (\xc3 9:00 - 10:00) text",
+			expected: "- (\xc3 9:00 - 10:00) text",
+		},
+		{
+			name:     "Invalid time stays as is",
+			input:    "- (A 9:00 - 25:00) broken",
+			expected: "- (A 9:00 - 25:00) broken",
+		},
+		{
+			name:     "Additional parts stay as is",
+			input:    "- (A 9:00 - 10:00 extra) text",
+			expected: "- (A 9:00 - 10:00 extra) text",
+		},
+		{
+			name:     "No task line",
+			input:    "Some  text (A 9:00 - 10:00)",
+			expected: "Some  text (A 9:00 - 10:00)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatTaskLine(tt.input)
+			if result != tt.expected {
+				t.Errorf("FormatTaskLine(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name: "Align expection block",
+			input: []string{
+				"> - Planned Work a: 160h",
+				"> - Meetings M:20h",
+				"",
+				"> - Support S: 5h",
+			},
+			expected: []string{
+				"> - Planned Work A: 160h",
+				"> - Meetings M:     20h",
+				"",
+				"> - Support S: 5h",
+			},
+		},
+		{
+			name: "Sort entries with nested lines",
+			input: []string{
+				"## 2025-10-09",
+				"- (M 10:00 - 11:00) meeting",
+				"  - (Mm 10:00 - 10:15) standup",
+				"  - notes",
+				"- (A 9:00 - 10:00) work",
+				"- (S 30m) support after work",
+				"",
+				"- (A 8:00 - 9:00) separate list",
+			},
+			expected: []string{
+				"## 2025-10-09",
+				"- (A 09:00 - 10:00) work",
+				"- (S 30m) support after work",
+				"- (M 10:00 - 11:00) meeting",
+				"  - (Mm 10:00 - 10:15) standup",
+				"  - notes",
+				"",
+				"- (A 08:00 - 09:00) separate list",
+			},
+		},
+		{
+			name: "Other content stays as is",
+			input: []string{
+				"# Timebook  ",
+				"* some\tnote",
+				"> quote",
+			},
+			expected: []string{
+				"# Timebook  ",
+				"* some\tnote",
+				"> quote",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := ParseDocument([]byte(strings.Join(tt.input, "\n"))).Lines
+			result := FormatLines(lines)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("FormatLines(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Line endings",
+			input:    "## 2025-10-09\r\n- (a 9:00 - 10:00) work\r\n",
+			expected: "## 2025-10-09\r\n- (A 09:00 - 10:00) work\r\n",
+		},
+		{
+			name:     "Front matter",
+			input:    "---\nnote: - (a 9:00 - 10:00)\n---\n- (a 9:00 - 10:00)",
+			expected: "---\nnote: - (a 9:00 - 10:00)\n---\n- (A 09:00 - 10:00)",
+		},
+		{
+			name:     "Sort ranges without spaces",
+			input:    "- (m 10:00-11:00) meeting\n- (a 9:00 -10:00)work\n",
+			expected: "- (A 09:00 - 10:00) work\n- (M 10:00 - 11:00) meeting\n",
+		},
+		{
+			name:     "Empty content",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := string(FormatContent([]byte(tt.input)))
+			if result != tt.expected {
+				t.Errorf("FormatContent(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+
+			// formatting the canonical form again changes nothing
+			if again := string(FormatContent([]byte(result))); again != result {
+				t.Errorf("FormatContent(%q) = %q; want %q", result, again, result)
+			}
+		})
+	}
+}
+
+func FuzzFormatContent(f *testing.F) {
+	f.Add("## 2025-10-09\n- (a 9:00 - 10:00) work\n  - (mm 9:30-9:45) standup\n")
+	f.Add("---\ntitle: x\n---\n> - Planned Work a: 160h\n> - Meetings M:20h\n")
+	f.Add("- (ü 9:00 - 10:00)\n- (Mü 8:00 - 9:00) Übung\r\n")
+	f.Add("- (S 22:00 - 1:00+1) incident\n- (A 14:05 - ) running\n- (s 45m)")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		result := FormatContent([]byte(content))
+
+		if utf8.ValidString(content) && !utf8.Valid(result) {
+			t.Errorf("FormatContent(%q) = %q; want valid UTF-8", content, result)
+		}
+
+		// formatting the canonical form again changes nothing
+		if again := FormatContent(result); !bytes.Equal(again, result) {
+			t.Errorf("FormatContent(%q) = %q; want %q", result, again, result)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "strings"
+
+// Delimiter of a front matter block
+const frontMatterDelimiter = "---"
+
+// Alternative delimiter closing a front matter block, as known from YAML documents
+const frontMatterEndDelimiter = "..."
+
+// Longest front matter block in lines, including its delimiters
+// Longer blocks are read as content.
+const maxFrontMatterLines = 1000
+
+// Check whether a line opens a front matter block
+func isFrontMatterStart(line string) bool {
+	return strings.TrimRight(line, " \t") == frontMatterDelimiter
+}
+
+// Check whether a line closes a front matter block
+func isFrontMatterEnd(line string) bool {
+	trimmedLine := strings.TrimRight(line, " \t")
+	return trimmedLine == frontMatterDelimiter || trimmedLine == frontMatterEndDelimiter
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFrontMatterLines(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         []string
+		expectedLines int
+	}{
+		{
+			name:          "Front matter block",
+			input:         []string{"---", "person: Jane Doe", "locale: en", "---", "## 2025-10-09"},
+			expectedLines: 4,
+		},
+		{
+			name:          "Closed by dots",
+			input:         []string{"---", "person: Jane Doe", "...", "- (A 9:00 - 10:00)"},
+			expectedLines: 3,
+		},
+		{
+			name:          "Empty block",
+			input:         []string{"---", "---"},
+			expectedLines: 2,
+		},
+		{
+			name:          "Trailing spaces after delimiters",
+			input:         []string{"--- ", "person: Jane Doe", "---\t"},
+			expectedLines: 3,
+		},
+		{
+			name:          "Longest block",
+			input:         append(append([]string{"---"}, make([]string, maxFrontMatterLines-2)...), "---"),
+			expectedLines: maxFrontMatterLines,
+		},
+		{
+			name:  "Block longer than the longest block",
+			input: append(append([]string{"---"}, make([]string, maxFrontMatterLines-1)...), "---"),
+		},
+		{
+			name:  "Unclosed block",
+			input: []string{"---", "person: Jane Doe"},
+		},
+		{
+			name:  "Block not at the start",
+			input: []string{"# Timebook", "---", "person: Jane Doe", "---"},
+		},
+		{
+			name:  "Empty input",
+			input: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := ParseDocument([]byte(strings.Join(tt.input, "\n")))
+			if document.FrontMatterLines != tt.expectedLines {
+				t.Errorf("ParseDocument(%q) front matter lines = %d; want %d", tt.input, document.FrontMatterLines, tt.expectedLines)
+			}
+
+			for _, line := range document.Lines {
+				if isFrontMatter := line.Number <= tt.expectedLines; isFrontMatter != (line.Kind == LineKindFrontMatter) {
+					t.Errorf("line %d kind = %v; want front matter %v", line.Number, line.Kind, isFrontMatter)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+// Reader of a timebook, parsing one line after the other
+// Lines are parsed the same way as by ParseDocument. Memory is bounded by the
+// longest line and the front matter block, not by the size of the input.
+type LineScanner struct {
+	reader *bufio.Reader
+	err    error
+
+	// The current line, valid after Scan returned true
+	line DocumentLine
+	// Lines read ahead while looking for the end of the front matter
+	pending []DocumentLine
+
+	frontMatterChecked bool
+	number             int
+	offset             int
+	currentDate        time.Time
+}
+
+// Create a scanner reading lines from the given reader
+func NewLineScanner(reader io.Reader) *LineScanner {
+	return &LineScanner{reader: bufio.NewReader(reader)}
+}
+
+// Advance to the next line
+// Returns false at the end of the input or if reading failed, see Err.
+func (s *LineScanner) Scan() bool {
+	if !s.frontMatterChecked {
+		s.frontMatterChecked = true
+		s.readFrontMatter()
+	}
+
+	if len(s.pending) > 0 {
+		s.line = s.pending[0]
+		s.pending = s.pending[1:]
+	} else if line, ok := s.readLine(); ok {
+		s.line = line
+	} else {
+		return false
+	}
+
+	if s.line.Kind == "" {
+		s.parseLine(&s.line)
+	}
+	return true
+}
+
+// Get the current line
+func (s *LineScanner) Line() DocumentLine {
+	return s.line
+}
+
+// Get the first error of the reader, nil at the end of the input
+func (s *LineScanner) Err() error {
+	return s.err
+}
+
+// Read a single line with its position, without parsing it
+func (s *LineScanner) readLine() (DocumentLine, bool) {
+	if s.err != nil {
+		return DocumentLine{}, false
+	}
+
+	text, err := s.reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.err = err
+		return DocumentLine{}, false
+	}
+	if text == "" {
+		return DocumentLine{}, false
+	}
+
+	ending := ""
+	if trimmedText, ok := strings.CutSuffix(text, "\n"); ok {
+		text, ending = trimmedText, "\n"
+		if trimmedText, ok := strings.CutSuffix(text, "\r"); ok {
+			text, ending = trimmedText, "\r\n"
+		}
+	}
+
+	s.number++
+	line := DocumentLine{
+		Number:      s.number,
+		Offset:      s.offset,
+		Text:        text,
+		Ending:      ending,
+		IndentWidth: IndentWidth(text),
+	}
+	s.offset += len(text) + len(ending)
+
+	return line, true
+}
+
+// Read ahead to find a front matter block at the start of the input
+// Read lines are kept pending, those of a closed block are marked as front matter.
+func (s *LineScanner) readFrontMatter() {
+	line, ok := s.readLine()
+	if !ok {
+		return
+	}
+	s.pending = append(s.pending, line)
+	if !isFrontMatterStart(line.Text) {
+		return
+	}
+
+	for len(s.pending) < maxFrontMatterLines {
+		line, ok := s.readLine()
+		if !ok {
+			return
+		}
+		s.pending = append(s.pending, line)
+
+		if isFrontMatterEnd(line.Text) {
+			for index := range s.pending {
+				s.pending[index].Kind = LineKindFrontMatter
+			}
+			return
+		}
+	}
+}
+
+// Determine the kind of a line and parse its content
+func (s *LineScanner) parseLine(line *DocumentLine) {
+	if level, text, ok := ParseHeading(line.Text); ok {
+		line.Kind = LineKindHeading
+		line.HeadingLevel = level
+		line.HeadingText = text
+
+		if date, ok := ParseDateHeading(line.Text); ok {
+			line.Date = date
+			s.currentDate = date
+		}
+		return
+	}
+
+	if trimmedLine, ok := FilterAndTrimLine(line.Text); ok {
+		line.Kind = LineKindEntry
+		line.Date = s.currentDate
+		line.Task, line.Err = parseDocumentTask(trimmedLine, s.currentDate)
+		return
+	}
+
+	if expection, err := ParseExpectionLine(line.Text); !errors.Is(err, ErrNoExpectionLine) {
+		line.Kind = LineKindExpection
+		line.Expection, line.Err = expection, err
+		return
+	}
+
+	if note, ok := ParseNoteLine(line.Text); ok {
+		line.Kind = LineKindNote
+		line.Note = note
+		return
+	}
+
+	line.Kind = LineKindText
+}
+
+// Parse the trimmed line of an entry to a task of the given day
+func parseDocumentTask(trimmedLine string, date time.Time) (*ParsedTask, error) {
+	raw, err := ParseTaskLine(trimmedLine)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := ConvertRawToParsed(raw)
+	if err != nil {
+		return nil, err
+	}
+	task.Date = date
+
+	return task, nil
+}
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineScanner(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedKinds []LineKind
+	}{
+		{
+			name:          "Front matter",
+			input:         "---\nperson: Jane Doe\n---\n- (A 9:00 - 10:00)\n",
+			expectedKinds: []LineKind{LineKindFrontMatter, LineKindFrontMatter, LineKindFrontMatter, LineKindEntry},
+		},
+		{
+			name:          "Unclosed front matter",
+			input:         "---\n## 2025-10-09\n- (A 9:00 - 10:00)",
+			expectedKinds: []LineKind{LineKindText, LineKindHeading, LineKindEntry},
+		},
+		{
+			name:          "Too long front matter",
+			input:         "---\n" + strings.Repeat("key: value\n", maxFrontMatterLines) + "---\n",
+			expectedKinds: repeatKind(LineKindText, maxFrontMatterLines+2),
+		},
+		{
+			name:          "No front matter",
+			input:         "> - Planned A: 20h\n\n  - note",
+			expectedKinds: []LineKind{LineKindExpection, LineKindText, LineKindNote},
+		},
+		{
+			name:          "Empty input",
+			input:         "",
+			expectedKinds: []LineKind{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// read byte by byte, so lines are spread over several reads
+			scanner := NewLineScanner(iotest.OneByteReader(strings.NewReader(tt.input)))
+
+			kinds := make([]LineKind, 0)
+			var content strings.Builder
+			for scanner.Scan() {
+				line := scanner.Line()
+				kinds = append(kinds, line.Kind)
+
+				if line.Number != len(kinds) || line.Offset != content.Len() {
+					t.Errorf("line %d has number %d and offset %d; want %d and %d", len(kinds), line.Number, line.Offset, len(kinds), content.Len())
+				}
+				content.WriteString(line.Text + line.Ending)
+			}
+
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("Err() = %v; want nil", err)
+			}
+			if fmt.Sprint(kinds) != fmt.Sprint(tt.expectedKinds) {
+				t.Errorf("kinds = %v; want %v", kinds, tt.expectedKinds)
+			}
+			if content.String() != tt.input {
+				t.Errorf("content = %q; want %q", content.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestLineScannerReadError(t *testing.T) {
+	readErr := errors.New("disk gone")
+	reader := io.MultiReader(strings.NewReader("## 2025-10-09\n- (A 9:00 - 10:00)\n"), iotest.ErrReader(readErr))
+
+	document, err := ReadDocument(reader)
+	if !errors.Is(err, readErr) {
+		t.Errorf("ReadDocument() error = %v; want %v", err, readErr)
+	}
+	if len(document.Lines) != 2 {
+		t.Errorf("ReadDocument() read %d lines; want 2", len(document.Lines))
+	}
+}
+
+func repeatKind(kind LineKind, count int) []LineKind {
+	kinds := make([]LineKind, count)
+	for i := range kinds {
+		kinds[i] = kind
+	}
+	return kinds
+}
+
+// Build a timebook archive with the given number of lines
+func benchmarkArchive(lineCount int) string {
+	var builder strings.Builder
+	for i := 0; i < lineCount; i++ {
+		switch i % 10 {
+		case 0:
+			fmt.Fprintf(&builder, "## %d.%d.%d\n", i%28+1, i%12+1, 2000+i%25)
+		case 1:
+			builder.WriteString("\n")
+		case 9:
+			builder.WriteString("  - discussed the rollout\n")
+		default:
+			fmt.Fprintf(&builder, "- (A %d:00 - %d:30) Fix login bug JIRA-%d #backend\n", i%10+7, i%10+7, i)
+		}
+	}
+
+	return builder.String()
+}
+
+// Scanning time per line stays the same for growing archives
+func BenchmarkLineScanner(b *testing.B) {
+	for _, lineCount := range []int{1_000, 10_000, 100_000, 300_000} {
+		archive := benchmarkArchive(lineCount)
+
+		b.Run(fmt.Sprintf("lines=%d", lineCount), func(b *testing.B) {
+			b.SetBytes(int64(len(archive)))
+			for i := 0; i < b.N; i++ {
+				scanner := NewLineScanner(strings.NewReader(archive))
+				for scanner.Scan() {
+				}
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*lineCount), "ns/line")
+		})
+	}
+}
+
+func BenchmarkRetrieveLinesFromContent(b *testing.B) {
+	for _, lineCount := range []int{1_000, 10_000, 100_000, 300_000} {
+		archive := []byte(benchmarkArchive(lineCount))
+
+		b.Run(fmt.Sprintf("lines=%d", lineCount), func(b *testing.B) {
+			b.SetBytes(int64(len(archive)))
+			for i := 0; i < b.N; i++ {
+				RetrieveLinesFromContent(archive)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*lineCount), "ns/line")
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returned for lines that are no expection lines at all
+var ErrNoExpectionLine = errors.New("not an expection line")
+
+// Error of a line that looks like timebook content but could not be parsed
+type ParseError struct {
+	// 1-based column within the parsed line the error relates to
+	Column  int
+	Message string
+}
+
+func newParseError(column int, format string, args ...any) *ParseError {
+	return &ParseError{
+		Column:  column,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// Get the 1-based column of the first occurrence of part in line
+// Searching starts at the given 1-based column, returns 0 if not found.
+func columnOf(line string, part string, fromColumn int) int {
+	if fromColumn < 1 || fromColumn > len(line) {
+		return 0
+	}
+
+	index := strings.Index(line[fromColumn-1:], part)
+	if index == -1 {
+		return 0
+	}
+
+	return fromColumn + index
+}